@@ -0,0 +1,5 @@
+data "rabbitmq_bindings" "orders" {
+  vhost             = "/"
+  source            = "orders"
+  routing_key_regex = "^orders\\.created\\."
+}
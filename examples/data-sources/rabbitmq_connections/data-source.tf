@@ -0,0 +1,8 @@
+data "rabbitmq_connections" "test_user" {
+  vhost = "/"
+  user  = "test"
+}
+
+output "test_user_clients" {
+  value = [for c in data.rabbitmq_connections.test_user.connections : "${c.client_provided_name} (${c.peer_host})"]
+}
@@ -0,0 +1,11 @@
+data "rabbitmq_consumers" "orders" {
+  vhost = "/"
+  queue = "orders"
+}
+
+check "orders_consumed" {
+  assert {
+    condition     = data.rabbitmq_consumers.orders.consumer_count > 0
+    error_message = "Queue orders has no consumers."
+  }
+}
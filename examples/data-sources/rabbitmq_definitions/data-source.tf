@@ -0,0 +1,10 @@
+data "rabbitmq_definitions" "backup" {
+  vhost                   = "/"
+  exclude_password_hashes = true
+  kinds                   = ["vhosts", "permissions", "policies", "queues", "exchanges", "bindings"]
+}
+
+resource "local_file" "definitions" {
+  filename = "${path.module}/definitions.json"
+  content  = data.rabbitmq_definitions.backup.json
+}
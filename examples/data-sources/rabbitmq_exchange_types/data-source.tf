@@ -0,0 +1,19 @@
+data "rabbitmq_exchange_types" "cluster" {}
+
+resource "rabbitmq_exchange" "delayed" {
+  name  = "delayed"
+  vhost = "/"
+  settings = {
+    type = "x-delayed-message"
+    arguments = {
+      "x-delayed-type" = "direct"
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.rabbitmq_exchange_types.cluster.names, "x-delayed-message")
+      error_message = "Enable the rabbitmq_delayed_message_exchange plugin before creating delayed exchanges."
+    }
+  }
+}
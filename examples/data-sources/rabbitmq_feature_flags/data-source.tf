@@ -0,0 +1,8 @@
+data "rabbitmq_feature_flags" "cluster" {}
+
+check "stable_feature_flags_enabled" {
+  assert {
+    condition     = data.rabbitmq_feature_flags.cluster.all_stable_flags_enabled
+    error_message = "Enable these feature flags before upgrading: ${join(", ", data.rabbitmq_feature_flags.cluster.disabled_stable_flags)}"
+  }
+}
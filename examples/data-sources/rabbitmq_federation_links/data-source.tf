@@ -0,0 +1,11 @@
+data "rabbitmq_federation_links" "orders" {
+  vhost    = "/"
+  upstream = "dc2"
+}
+
+check "federation_running" {
+  assert {
+    condition     = data.rabbitmq_federation_links.orders.all_running
+    error_message = join("\n", [for l in data.rabbitmq_federation_links.orders.links : "${coalesce(l.exchange, l.queue)} from ${l.upstream} is ${l.status}: ${coalesce(l.error, "no error reported")}" if l.status != "running"])
+  }
+}
@@ -0,0 +1,19 @@
+data "rabbitmq_health_checks" "rollout" {
+  alarms                  = true
+  virtual_hosts           = true
+  node_is_quorum_critical = true
+  port_listeners          = [5672]
+  protocol_listeners      = ["amqp091"]
+
+  certificate_expiration = {
+    within = 4
+    unit   = "weeks"
+  }
+}
+
+check "broker_healthy" {
+  assert {
+    condition     = data.rabbitmq_health_checks.rollout.passed
+    error_message = join("\n", [for r in data.rabbitmq_health_checks.rollout.results : "${r.name}: ${r.reason}" if !r.passed])
+  }
+}
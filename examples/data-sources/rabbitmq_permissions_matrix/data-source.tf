@@ -0,0 +1,18 @@
+# Every permission and topic permission in the production vhosts.
+data "rabbitmq_permissions_matrix" "production" {
+  vhosts = ["orders", "billing"]
+}
+
+# Users that can configure every resource in any vhost.
+data "rabbitmq_permissions_matrix" "broad_configure" {
+  over_broad_on = ["configure"]
+}
+
+check "no_broad_configure" {
+  assert {
+    condition = alltrue([
+      for e in data.rabbitmq_permissions_matrix.broad_configure.entries : e.user == "admin"
+    ])
+    error_message = "Only admin may configure every resource."
+  }
+}
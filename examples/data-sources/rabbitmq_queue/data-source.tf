@@ -0,0 +1,21 @@
+data "rabbitmq_queue" "orders_dlq" {
+  name  = "orders.dlq"
+  vhost = "/"
+}
+
+data "rabbitmq_queue" "orders" {
+  name  = "orders"
+  vhost = "/"
+}
+
+check "orders_queues" {
+  assert {
+    condition     = data.rabbitmq_queue.orders_dlq.messages == 0
+    error_message = "${data.rabbitmq_queue.orders_dlq.messages} messages are dead-lettered in orders.dlq."
+  }
+
+  assert {
+    condition     = data.rabbitmq_queue.orders.consumer_count >= 2
+    error_message = "orders has ${data.rabbitmq_queue.orders.consumer_count} consumers, expected at least 2."
+  }
+}
@@ -0,0 +1,7 @@
+data "rabbitmq_queues" "orders" {
+  vhost     = "/"
+  name      = "^orders\\."
+  use_regex = true
+  page_size = 500
+  columns   = ["type", "messages_ready", "messages_unacknowledged", "consumers"]
+}
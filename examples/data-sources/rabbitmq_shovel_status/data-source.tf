@@ -0,0 +1,11 @@
+data "rabbitmq_shovel_status" "orders" {
+  vhost = "/"
+  name  = "orders-to-dr"
+}
+
+check "orders_shovel_running" {
+  assert {
+    condition     = alltrue([for s in data.rabbitmq_shovel_status.orders.shovels : s.state == "running"])
+    error_message = join("\n", [for s in data.rabbitmq_shovel_status.orders.shovels : "${s.name} is ${s.state}: ${coalesce(s.reason, "no reason given")}" if s.state != "running"])
+  }
+}
@@ -0,0 +1,11 @@
+data "rabbitmq_stream_connections" "events" {
+  vhost  = "/"
+  stream = "events"
+}
+
+check "events_consumers_keep_up" {
+  assert {
+    condition     = data.rabbitmq_stream_connections.events.max_offset_lag < 100000
+    error_message = "A consumer of the events stream is ${data.rabbitmq_stream_connections.events.max_offset_lag} offsets behind."
+  }
+}
@@ -0,0 +1,3 @@
+data "rabbitmq_user_permissions" "test" {
+  user = "test"
+}
@@ -0,0 +1,12 @@
+data "rabbitmq_whoami" "current" {}
+
+resource "rabbitmq_vhost" "test" {
+  name = "test"
+
+  lifecycle {
+    precondition {
+      condition     = data.rabbitmq_whoami.current.is_administrator
+      error_message = "The provider user ${data.rabbitmq_whoami.current.name} needs the administrator tag."
+    }
+  }
+}
@@ -4,19 +4,28 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ provider.Provider = (*RabbitmqProvider)(nil)
@@ -31,60 +40,220 @@ func New() func() provider.Provider {
 }
 
 type RabbitmqProviderModel struct {
-	Address        types.String `tfsdk:"address"`
-	Username       types.String `tfsdk:"username"`
-	Password       types.String `tfsdk:"password"`
-	Insecure       types.Bool   `tfsdk:"insecure"`
-	CacertFile     types.String `tfsdk:"cacert_file"`
-	ClientcertFile types.String `tfsdk:"clientcert_file"`
-	ClientkeyFile  types.String `tfsdk:"clientkey_file"`
-	Proxy          types.String `tfsdk:"proxy"`
+	Address               types.String                 `tfsdk:"address"`
+	Endpoints             types.List                   `tfsdk:"endpoints"`
+	Username              types.String                 `tfsdk:"username"`
+	Password              types.String                 `tfsdk:"password"`
+	Insecure              types.Bool                   `tfsdk:"insecure"`
+	CacertFile            types.String                 `tfsdk:"cacert_file"`
+	ClientcertFile        types.String                 `tfsdk:"clientcert_file"`
+	ClientkeyFile         types.String                 `tfsdk:"clientkey_file"`
+	SkipConnectivityCheck types.Bool                   `tfsdk:"skip_connectivity_check"`
+	CacertPem             types.String                 `tfsdk:"cacert_pem"`
+	ClientcertPem         types.String                 `tfsdk:"clientcert_pem"`
+	ClientkeyPem          types.String                 `tfsdk:"clientkey_pem"`
+	Proxy                 types.String                 `tfsdk:"proxy"`
+	Token                 types.String                 `tfsdk:"token"`
+	DefaultVhost          types.String                 `tfsdk:"default_vhost"`
+	Headers               types.Map                    `tfsdk:"headers"`
+	OAuth2                *RabbitmqProviderOAuth2Model `tfsdk:"oauth2"`
+	Retry                 *RabbitmqProviderRetryModel  `tfsdk:"retry"`
+	ConnectTimeout        types.String                 `tfsdk:"connect_timeout"`
+	TLSHandshakeTimeout   types.String                 `tfsdk:"tls_handshake_timeout"`
+	RequestTimeout        types.String                 `tfsdk:"request_timeout"`
+	MaxConcurrentRequests types.Int64                  `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64                `tfsdk:"requests_per_second"`
+}
+
+type RabbitmqProviderRetryModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	BaseBackoff          types.String `tfsdk:"base_backoff"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+}
+
+type RabbitmqProviderOAuth2Model struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
 }
 
 type RabbitmqProviderData struct {
 	rabbitmqClient *rabbithole.Client
+	transport      http.RoundTripper
+	// rabbitmqVersion is the broker version reported by /api/overview, or
+	// empty when the connectivity check is skipped.
+	rabbitmqVersion string
+	// defaultVhost is the vhost of resources that do not set one.
+	defaultVhost string
 }
 
 func (p *RabbitmqProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"address": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Description: "The address of the RabbitMQ management API, e.g. http://localhost:15672. Conflicts with endpoints. Can also be set with the RABBITMQ_ENDPOINT environment variable.",
+			},
+			"endpoints": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The addresses of several management API nodes, tried in order. When the node in use cannot be reached or answers with a 5xx status, the next one is used for the rest of the run. Conflicts with address. Can also be set with the comma separated RABBITMQ_ENDPOINTS environment variable.",
 			},
 			"username": schema.StringAttribute{
-				Required:  true,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The username to authenticate with. Can also be set with the RABBITMQ_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
-				Required:  true,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password to authenticate with. Can also be set with the RABBITMQ_PASSWORD environment variable.",
 			},
 			"insecure": schema.BoolAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Trust self-signed certificates. Can also be set with the RABBITMQ_INSECURE environment variable.",
+			},
+			"skip_connectivity_check": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip reading /api/overview and /api/whoami when the provider is configured. The check reports a wrong address or bad credentials once, before any resource is read, and detects the broker version. Can also be set with the RABBITMQ_SKIP_CONNECTIVITY_CHECK environment variable.",
 			},
 			"cacert_file": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Path to the CA certificate file. Can also be set with the RABBITMQ_CACERT environment variable.",
 			},
 			"clientcert_file": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Path to the client certificate file. Can also be set with the RABBITMQ_CLIENTCERT environment variable.",
 			},
 			"clientkey_file": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Path to the client key file. Can also be set with the RABBITMQ_CLIENTKEY environment variable.",
+			},
+			"cacert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates, as an alternative to cacert_file. Can also be set with the RABBITMQ_CACERT_PEM environment variable.",
+			},
+			"clientcert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate, as an alternative to clientcert_file. Can also be set with the RABBITMQ_CLIENTCERT_PEM environment variable.",
+			},
+			"clientkey_pem": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client key, as an alternative to clientkey_file. Can also be set with the RABBITMQ_CLIENTKEY_PEM environment variable.",
 			},
 			"proxy": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Proxy URL to use for requests. Can also be set with the RABBITMQ_PROXY environment variable.",
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "An OAuth 2.0 bearer token to authenticate with instead of username and password. Conflicts with oauth2. Can also be set with the RABBITMQ_TOKEN environment variable.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Extra HTTP headers to send with every management API request, e.g. for an authenticating reverse proxy or an API gateway. Authorization cannot be set, as it carries the credentials. Can also be set with the RABBITMQ_HEADERS environment variable as comma separated name=value pairs.",
+			},
+			"oauth2": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Fetch OAuth 2.0 bearer tokens with the client credentials grant and authenticate with them instead of username and password. Tokens are cached and refreshed before they expire. Conflicts with token.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Required:    true,
+						Description: "The token endpoint of the authorization server.",
+					},
+					"client_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The client ID.",
+					},
+					"client_secret": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "The client secret.",
+					},
+					"scopes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The scopes to request, e.g. rabbitmq.tag:administrator.",
+					},
+				},
+			},
+			"default_vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "The vhost of rabbitmq_exchange, rabbitmq_permissions and rabbitmq_topic_permissions resources that do not set one. Defaults to /. Can also be set with the RABBITMQ_DEFAULT_VHOST environment variable.",
+			},
+			"connect_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for a TCP connection to the management API. Defaults to 30s. Can also be set with the RABBITMQ_CONNECT_TIMEOUT environment variable.",
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for the TLS handshake. Defaults to 10s. Can also be set with the RABBITMQ_TLS_HANDSHAKE_TIMEOUT environment variable.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for each request, from sending it until its response has been read. Each retry gets its own timeout. 0 disables it. Defaults to 2m. Can also be set with the RABBITMQ_REQUEST_TIMEOUT environment variable.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests in flight at once, shared by all resources and data sources. Unlimited when unset. Can also be set with the RABBITMQ_MAX_CONCURRENT_REQUESTS environment variable.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests started per second, shared by all resources and data sources. Unlimited when unset. Can also be set with the RABBITMQ_REQUESTS_PER_SECOND environment variable.",
+			},
+			"retry": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Retry idempotent requests that fail with a connection error or a retryable status, e.g. while nodes restart. Requests are not retried when unset.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The maximum number of attempts per request, including the first one. Defaults to 3.",
+					},
+					"base_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: "The wait before the first retry, doubled for each further retry and jittered. Defaults to 500ms.",
+					},
+					"max_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: "The longest wait between attempts. Defaults to 10s.",
+					},
+					"retryable_status_codes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Int64Type,
+						Description: "The response statuses that are retried. Defaults to 502, 503 and 504.",
+					},
+				},
 			},
 		},
 	}
 }
 func (p *RabbitmqProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// The configuration may depend on values unknown until apply, such as the
+	// address of a broker created in the same run. The provider is then left
+	// unconfigured for this plan rather than failing it.
+	if !req.Config.Raw.IsFullyKnown() {
+		tflog.Info(ctx, "rabbitmq provider configuration is unknown until apply, leaving the provider unconfigured")
+		return
+	}
+
 	var data RabbitmqProviderModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	rabbitmqClient, err := configureRmqClient(&data)
+	resp.Diagnostics.Append(applyProviderEnv(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rabbitmqClient, transport, err := configureRmqClient(&data)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to configure RabbitMQ client", err.Error())
 		return
@@ -92,6 +261,44 @@ func (p *RabbitmqProvider) Configure(ctx context.Context, req provider.Configure
 
 	providerData := &RabbitmqProviderData{
 		rabbitmqClient: rabbitmqClient,
+		transport:      transport,
+		defaultVhost:   "/",
+	}
+	if v := data.DefaultVhost.ValueString(); v != "" {
+		providerData.defaultVhost = v
+	}
+
+	if !data.SkipConnectivityCheck.ValueBool() {
+		endpoint := data.Address.ValueString()
+		if len(data.Endpoints.Elements()) > 0 {
+			var endpoints []string
+			resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+			endpoint = strings.Join(endpoints, ", ")
+		}
+
+		rmqc := providerData.client(ctx)
+		whoami, err := rmqc.Whoami()
+		var overview *rabbithole.Overview
+		if err == nil {
+			overview, err = rmqc.Overview()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Connect to RabbitMQ",
+				fmt.Sprintf("Could not read /api/whoami and /api/overview from the RabbitMQ management API at %s: %s\n\n"+
+					"Check the address, credentials and TLS settings of the provider, and that the user has the management tag. "+
+					"Set skip_connectivity_check to configure the provider without contacting the broker.", endpoint, err.Error()),
+			)
+			return
+		}
+
+		providerData.rabbitmqVersion = overview.RabbitMQVersion
+		tflog.Info(ctx, "connected to rabbitmq", map[string]interface{}{
+			"user":               whoami.Name,
+			"node":               overview.Node,
+			"rabbitmq_version":   overview.RabbitMQVersion,
+			"management_version": overview.ManagementVersion,
+		})
 	}
 
 	resp.ResourceData = providerData
@@ -103,7 +310,23 @@ func (p *RabbitmqProvider) Metadata(ctx context.Context, req provider.MetadataRe
 }
 
 func (p *RabbitmqProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewRabbitmqQueueDataSource,
+		NewRabbitmqQueuesDataSource,
+		NewRabbitmqBindingsDataSource,
+		NewRabbitmqUserPermissionsDataSource,
+		NewRabbitmqWhoamiDataSource,
+		NewRabbitmqHealthChecksDataSource,
+		NewRabbitmqDefinitionsDataSource,
+		NewRabbitmqFeatureFlagsDataSource,
+		NewRabbitmqConnectionsDataSource,
+		NewRabbitmqConsumersDataSource,
+		NewRabbitmqExchangeTypesDataSource,
+		NewRabbitmqShovelStatusDataSource,
+		NewRabbitmqFederationLinksDataSource,
+		NewRabbitmqPermissionsMatrixDataSource,
+		NewRabbitmqStreamConnectionsDataSource,
+	}
 }
 
 func (p *RabbitmqProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -120,11 +343,221 @@ func (p *RabbitmqProvider) EphemeralResources(_ context.Context) []func() epheme
 	return []func() ephemeral.EphemeralResource{}
 }
 
-func configureRmqClient(model *RabbitmqProviderModel) (*rabbithole.Client, error) {
+// applyProviderEnv fills the provider attributes that are not set in the
+// configuration from their environment variables. A value in the configuration
+// always takes precedence, and endpoints takes precedence over address. Address
+// or endpoints must be set one way or the other, and so must username and
+// password unless a token or oauth2 replaces them. The configuration must be
+// fully known.
+func applyProviderEnv(data *RabbitmqProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.Address.IsNull() && !data.Endpoints.IsNull() {
+		diags.AddAttributeError(
+			path.Root("endpoints"),
+			"Conflicting RabbitMQ Provider Values",
+			"address and endpoints cannot both be set. Use endpoints alone to list several nodes.",
+		)
+	}
+	if data.Endpoints.IsNull() && data.Address.IsNull() {
+		if v := os.Getenv("RABBITMQ_ENDPOINTS"); v != "" {
+			var endpoints []attr.Value
+			for _, e := range strings.Split(v, ",") {
+				if e = strings.TrimSpace(e); e != "" {
+					endpoints = append(endpoints, types.StringValue(e))
+				}
+			}
+			data.Endpoints = types.ListValueMust(types.StringType, endpoints)
+		}
+	}
+	for i, e := range data.Endpoints.Elements() {
+		if e.IsUnknown() || e.IsNull() || e.(types.String).ValueString() == "" {
+			diags.AddAttributeError(
+				path.Root("endpoints").AtListIndex(i),
+				"Invalid RabbitMQ Provider Value",
+				fmt.Sprintf("endpoints must list the address of every node, but element %d is empty or unknown until apply.", i),
+			)
+		}
+	}
+
+	if data.Headers.IsNull() {
+		if v := os.Getenv("RABBITMQ_HEADERS"); v != "" {
+			headers := map[string]attr.Value{}
+			for _, h := range strings.Split(v, ",") {
+				if h = strings.TrimSpace(h); h == "" {
+					continue
+				}
+				name, value, ok := strings.Cut(h, "=")
+				if !ok {
+					diags.AddAttributeError(
+						path.Root("headers"),
+						"Invalid RabbitMQ Provider Value",
+						fmt.Sprintf("The RABBITMQ_HEADERS environment variable must hold comma separated name=value pairs, got %q.", h),
+					)
+					continue
+				}
+				headers[strings.TrimSpace(name)] = types.StringValue(strings.TrimSpace(value))
+			}
+			data.Headers = types.MapValueMust(types.StringType, headers)
+		}
+	}
+
+	// alternative names the attribute that replaces this one, such as the PEM
+	// form of a file attribute. The environment variable is not used when the
+	// alternative is set in the configuration, and both cannot be set.
+	settings := []struct {
+		attribute   string
+		env         string
+		value       *types.String
+		basicAuth   bool
+		alternative string
+	}{
+		{"address", "RABBITMQ_ENDPOINT", &data.Address, false, ""},
+		{"username", "RABBITMQ_USERNAME", &data.Username, true, ""},
+		{"password", "RABBITMQ_PASSWORD", &data.Password, true, ""},
+		{"cacert_file", "RABBITMQ_CACERT", &data.CacertFile, false, "cacert_pem"},
+		{"clientcert_file", "RABBITMQ_CLIENTCERT", &data.ClientcertFile, false, "clientcert_pem"},
+		{"clientkey_file", "RABBITMQ_CLIENTKEY", &data.ClientkeyFile, false, "clientkey_pem"},
+		{"cacert_pem", "RABBITMQ_CACERT_PEM", &data.CacertPem, false, "cacert_file"},
+		{"clientcert_pem", "RABBITMQ_CLIENTCERT_PEM", &data.ClientcertPem, false, "clientcert_file"},
+		{"clientkey_pem", "RABBITMQ_CLIENTKEY_PEM", &data.ClientkeyPem, false, "clientkey_file"},
+		{"proxy", "RABBITMQ_PROXY", &data.Proxy, false, ""},
+		{"token", "RABBITMQ_TOKEN", &data.Token, false, ""},
+		{"connect_timeout", "RABBITMQ_CONNECT_TIMEOUT", &data.ConnectTimeout, false, ""},
+		{"tls_handshake_timeout", "RABBITMQ_TLS_HANDSHAKE_TIMEOUT", &data.TLSHandshakeTimeout, false, ""},
+		{"request_timeout", "RABBITMQ_REQUEST_TIMEOUT", &data.RequestTimeout, false, ""},
+		{"default_vhost", "RABBITMQ_DEFAULT_VHOST", &data.DefaultVhost, false, ""},
+	}
+
+	configured := map[string]bool{}
+	for _, s := range settings {
+		configured[s.attribute] = !s.value.IsNull()
+	}
+
+	for _, s := range settings {
+		if s.value.IsNull() && !configured[s.alternative] {
+			if v := os.Getenv(s.env); v != "" {
+				*s.value = types.StringValue(v)
+			}
+		}
+	}
+
+	envs := map[string]string{}
+	values := map[string]string{}
+	for _, s := range settings {
+		envs[s.attribute] = s.env
+		values[s.attribute] = s.value.ValueString()
+	}
+	for _, s := range settings {
+		// Each pair is reported once, on the file attribute.
+		if s.alternative == "" || !strings.HasSuffix(s.attribute, "_file") {
+			continue
+		}
+		if values[s.attribute] != "" && values[s.alternative] != "" {
+			diags.AddAttributeError(
+				path.Root(s.attribute),
+				"Conflicting RabbitMQ Provider Values",
+				fmt.Sprintf("%s and %s cannot both be set. They may come from the %s and %s environment variables.", s.attribute, s.alternative, s.env, envs[s.alternative]),
+			)
+		}
+	}
+
+	if data.Address.ValueString() == "" && len(data.Endpoints.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("address"),
+			"Missing RabbitMQ Provider Value",
+			"The provider cannot be configured because neither address nor endpoints is set. Set address or endpoints in the provider configuration, or the RABBITMQ_ENDPOINT or RABBITMQ_ENDPOINTS environment variable.",
+		)
+	}
+
+	bearer := data.Token.ValueString() != "" || data.OAuth2 != nil
+	if data.Token.ValueString() != "" && data.OAuth2 != nil {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Conflicting RabbitMQ Provider Values",
+			"token and oauth2 cannot both be set. The token may come from the RABBITMQ_TOKEN environment variable.",
+		)
+	}
+
+	for _, s := range settings {
+		if s.basicAuth && !bearer && s.value.ValueString() == "" {
+			diags.AddAttributeError(
+				path.Root(s.attribute),
+				"Missing RabbitMQ Provider Value",
+				fmt.Sprintf("The provider cannot be configured because %s is not set. Set %s in the provider configuration or the %s environment variable.", s.attribute, s.attribute, s.env),
+			)
+		}
+	}
+
+	bools := []struct {
+		attribute string
+		env       string
+		value     *types.Bool
+	}{
+		{"insecure", "RABBITMQ_INSECURE", &data.Insecure},
+		{"skip_connectivity_check", "RABBITMQ_SKIP_CONNECTIVITY_CHECK", &data.SkipConnectivityCheck},
+	}
+	for _, b := range bools {
+		if b.value.IsNull() {
+			if v := os.Getenv(b.env); v != "" {
+				parsed, err := strconv.ParseBool(v)
+				if err != nil {
+					diags.AddAttributeError(
+						path.Root(b.attribute),
+						"Invalid RabbitMQ Provider Value",
+						fmt.Sprintf("The %s environment variable must be true or false, got %q.", b.env, v),
+					)
+					continue
+				}
+				*b.value = types.BoolValue(parsed)
+			}
+		}
+	}
+
+	if data.MaxConcurrentRequests.IsNull() {
+		if v := os.Getenv("RABBITMQ_MAX_CONCURRENT_REQUESTS"); v != "" {
+			maxConcurrent, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("max_concurrent_requests"),
+					"Invalid RabbitMQ Provider Value",
+					fmt.Sprintf("The RABBITMQ_MAX_CONCURRENT_REQUESTS environment variable must be a whole number, got %q.", v),
+				)
+			} else {
+				data.MaxConcurrentRequests = types.Int64Value(maxConcurrent)
+			}
+		}
+	}
+
+	if data.RequestsPerSecond.IsNull() {
+		if v := os.Getenv("RABBITMQ_REQUESTS_PER_SECOND"); v != "" {
+			perSecond, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("requests_per_second"),
+					"Invalid RabbitMQ Provider Value",
+					fmt.Sprintf("The RABBITMQ_REQUESTS_PER_SECOND environment variable must be a number, got %q.", v),
+				)
+			} else {
+				data.RequestsPerSecond = types.Float64Value(perSecond)
+			}
+		}
+	}
+
+	return diags
+}
+
+func configureRmqClient(model *RabbitmqProviderModel) (*rabbithole.Client, http.RoundTripper, error) {
 
 	var username = model.Username.ValueString()
 	var password = model.Password.ValueString()
-	var endpoint = model.Address.ValueString()
+	var endpoints = []string{model.Address.ValueString()}
+	if len(model.Endpoints.Elements()) > 0 {
+		endpoints = nil
+		for _, e := range model.Endpoints.Elements() {
+			endpoints = append(endpoints, e.(types.String).ValueString())
+		}
+	}
 	var insecure = model.Insecure.ValueBool()
 	var cacertFile = model.CacertFile.ValueString()
 	var clientcertFile = model.ClientcertFile.ValueString()
@@ -132,20 +565,33 @@ func configureRmqClient(model *RabbitmqProviderModel) (*rabbithole.Client, error
 	var proxy = model.Proxy.ValueString()
 
 	tlsConfig := &tls.Config{}
-	if cacertFile != "" {
-		caCert, err := os.ReadFile(cacertFile)
-		if err != nil {
-			return nil, err
-		}
-
+	caCert, caCertSource, err := readPEM("cacert", cacertFile, model.CacertPem.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	if caCert != nil {
 		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, nil, fmt.Errorf("%s contains no PEM encoded certificates", caCertSource)
+		}
 		tlsConfig.RootCAs = caCertPool
 	}
-	if clientcertFile != "" && clientkeyFile != "" {
-		clientPair, err := tls.LoadX509KeyPair(clientcertFile, clientkeyFile)
+
+	clientCert, clientCertSource, err := readPEM("clientcert", clientcertFile, model.ClientcertPem.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	clientKey, clientKeySource, err := readPEM("clientkey", clientkeyFile, model.ClientkeyPem.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, nil, fmt.Errorf("a client certificate and a client key must be set together, only %s is set", clientCertSource+clientKeySource)
+	}
+	if clientCert != nil {
+		clientPair, err := tls.X509KeyPair(clientCert, clientKey)
 		if err != nil {
-			return nil, err
+			return nil, nil, fmt.Errorf("invalid client certificate or key in %s and %s: %w", clientCertSource, clientKeySource, err)
 		}
 		tlsConfig.Certificates = []tls.Certificate{clientPair}
 	}
@@ -153,17 +599,36 @@ func configureRmqClient(model *RabbitmqProviderModel) (*rabbithole.Client, error
 		tlsConfig.InsecureSkipVerify = true
 	}
 
+	connectTimeout, err := parseTimeout("connect_timeout", model.ConnectTimeout, 30*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsHandshakeTimeout, err := parseTimeout("tls_handshake_timeout", model.TLSHandshakeTimeout, 10*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	requestTimeout, err := parseTimeout("request_timeout", model.RequestTimeout, 2*time.Minute)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var proxyURL *url.URL
 	if proxy != "" {
 		var err error
 		proxyURL, err = url.Parse(proxy)
 		if err != nil {
-			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+			return nil, nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
 		}
 	}
 
-	transport := &http.Transport{
-		TLSClientConfig: tlsConfig,
+	dialer := &net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	baseTransport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: tlsHandshakeTimeout,
+		TLSClientConfig:     tlsConfig,
 		Proxy: func(req *http.Request) (*url.URL, error) {
 			if proxyURL != nil {
 				return proxyURL, nil
@@ -173,10 +638,237 @@ func configureRmqClient(model *RabbitmqProviderModel) (*rabbithole.Client, error
 		},
 	}
 
-	rabbitmqClient, err := rabbithole.NewTLSClient(endpoint, username, password, transport)
+	var transport http.RoundTripper = baseTransport
+	if requestTimeout > 0 {
+		transport = &timeoutTransport{base: transport, timeout: requestTimeout}
+	}
+	if model.MaxConcurrentRequests.ValueInt64() < 0 {
+		return nil, nil, fmt.Errorf("invalid max_concurrent_requests %d: must not be negative", model.MaxConcurrentRequests.ValueInt64())
+	}
+	if model.RequestsPerSecond.ValueFloat64() < 0 {
+		return nil, nil, fmt.Errorf("invalid requests_per_second %g: must not be negative", model.RequestsPerSecond.ValueFloat64())
+	}
+	if model.MaxConcurrentRequests.ValueInt64() > 0 || model.RequestsPerSecond.ValueFloat64() > 0 {
+		transport = newLimitTransport(transport, int(model.MaxConcurrentRequests.ValueInt64()), model.RequestsPerSecond.ValueFloat64())
+	}
+	if len(endpoints) > 1 {
+		failover, err := newFailoverTransport(transport, endpoints)
+		if err != nil {
+			return nil, nil, err
+		}
+		transport = failover
+	}
+	if model.Retry != nil {
+		retry, err := newRetryTransport(transport, model.Retry)
+		if err != nil {
+			return nil, nil, err
+		}
+		transport = retry
+	}
+	if len(model.Headers.Elements()) > 0 {
+		header := http.Header{}
+		for name, value := range model.Headers.Elements() {
+			v := value.(types.String).ValueString()
+			if !validHeaderName(name) || strings.ContainsAny(v, "\r\n") {
+				return nil, nil, fmt.Errorf("invalid header %q: the name must be a valid HTTP header name and the value must not contain line breaks", name)
+			}
+			if strings.EqualFold(name, "Authorization") {
+				return nil, nil, fmt.Errorf("invalid header %q: use username and password, token or oauth2 to authenticate", name)
+			}
+			header.Set(name, v)
+		}
+		transport = &headerTransport{base: transport, header: header}
+	}
+	if token := model.Token.ValueString(); token != "" {
+		transport = &bearerTransport{base: transport, source: staticToken(token)}
+	} else if model.OAuth2 != nil {
+		var scopes []string
+		for _, scope := range model.OAuth2.Scopes.Elements() {
+			scopes = append(scopes, scope.(types.String).ValueString())
+		}
+		transport = &bearerTransport{
+			base: transport,
+			source: &oauth2TokenSource{
+				tokenURL:     model.OAuth2.TokenURL.ValueString(),
+				clientID:     model.OAuth2.ClientID.ValueString(),
+				clientSecret: model.OAuth2.ClientSecret.ValueString(),
+				scopes:       scopes,
+				client:       &http.Client{Transport: baseTransport, Timeout: requestTimeout},
+			},
+		}
+	}
+
+	rabbitmqClient, err := rabbithole.NewTLSClient(endpoints[0], username, password, transport)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return rabbitmqClient, transport, nil
+}
+
+// readPEM returns the PEM content of the named TLS setting, read from file or
+// given inline, along with a description of where it came from for errors.
+// It returns nil when neither is set.
+func readPEM(name, file, pem string) ([]byte, string, error) {
+	if pem != "" {
+		return []byte(pem), name + "_pem", nil
+	}
+	if file == "" {
+		return nil, "", nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read %s_file: %w", name, err)
+	}
+	return content, fmt.Sprintf("%s_file %s", name, file), nil
+}
+
+// parseTimeout parses a timeout attribute, returning def when it is unset.
+func parseTimeout(attribute string, value types.String, def time.Duration) (time.Duration, error) {
+	if value.ValueString() == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected a duration such as 30s", attribute, value.ValueString())
+	}
+	return d, nil
+}
+
+// unconfiguredProviderData stands in for the data of a provider left
+// unconfigured because its configuration is unknown until apply, so that
+// resources read during such a plan fail with an error saying so.
+var unconfiguredProviderData = func() *RabbitmqProviderData {
+	rmqc, _ := rabbithole.NewTLSClient("http://localhost:15672", "", "", unconfiguredTransport{})
+	return &RabbitmqProviderData{
+		rabbitmqClient: rmqc,
+		transport:      unconfiguredTransport{},
+		defaultVhost:   "/",
+	}
+}()
+
+// client returns the RabbitMQ client with its requests tied to ctx, so that
+// they are cancelled when Terraform cancels the operation.
+func (d *RabbitmqProviderData) client(ctx context.Context) *rabbithole.Client {
+	if d == nil {
+		d = unconfiguredProviderData
+	}
+	rmqc := *d.rabbitmqClient
+	rmqc.SetTransport(&contextTransport{base: d.transport, ctx: ctx})
+	return &rmqc
+}
+
+// newRetryTransport wraps base in a retryTransport configured by the retry
+// attribute, applying its defaults.
+func newRetryTransport(base http.RoundTripper, model *RabbitmqProviderRetryModel) (*retryTransport, error) {
+	t := &retryTransport{
+		base:        base,
+		maxAttempts: 3,
+		baseBackoff: 500 * time.Millisecond,
+		maxBackoff:  10 * time.Second,
+		statusCodes: map[int]bool{502: true, 503: true, 504: true},
+	}
+
+	if !model.MaxAttempts.IsNull() {
+		if model.MaxAttempts.ValueInt64() < 1 {
+			return nil, fmt.Errorf("invalid retry.max_attempts %d: must be at least 1", model.MaxAttempts.ValueInt64())
+		}
+		t.maxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+	if !model.BaseBackoff.IsNull() {
+		d, err := time.ParseDuration(model.BaseBackoff.ValueString())
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid retry.base_backoff %q: expected a duration such as 500ms", model.BaseBackoff.ValueString())
+		}
+		t.baseBackoff = d
+	}
+	if !model.MaxBackoff.IsNull() {
+		d, err := time.ParseDuration(model.MaxBackoff.ValueString())
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid retry.max_backoff %q: expected a duration such as 10s", model.MaxBackoff.ValueString())
+		}
+		t.maxBackoff = d
+	}
+	if t.maxBackoff < t.baseBackoff {
+		return nil, fmt.Errorf("invalid retry.max_backoff %s: must not be shorter than retry.base_backoff %s", t.maxBackoff, t.baseBackoff)
+	}
+	if !model.RetryableStatusCodes.IsNull() {
+		t.statusCodes = map[int]bool{}
+		for _, code := range model.RetryableStatusCodes.Elements() {
+			t.statusCodes[int(code.(types.Int64).ValueInt64())] = true
+		}
+	}
+
+	return t, nil
+}
+
+// planVhost plans the vhost of a resource. A vhost left unset in the
+// configuration is planned as default_vhost, so that it is known at plan time
+// rather than after apply, and a change of vhost replaces the resource. It is
+// called from ModifyPlan rather than an attribute plan modifier because it
+// needs the provider configuration.
+func (d *RabbitmqProviderData) planVhost(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vhost"), &config)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("vhost"), &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The provider is not configured yet when its configuration depends on
+	// values unknown until apply, leaving the vhost unknown too.
+	if config.IsNull() && d != nil {
+		plan = types.StringValue(d.defaultVhost)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("vhost"), plan)...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("vhost"), &state)...)
+	if !plan.Equal(state) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("vhost"))
+	}
+}
+
+// getJSON sends a GET request to a management API path that rabbit-hole has no
+// method for, through the same transport as the client, and decodes the
+// response into rec. Error responses are returned as rabbithole.ErrorResponse.
+func (d *RabbitmqProviderData) getJSON(ctx context.Context, path string, rec interface{}) error {
+	if d == nil {
+		d = unconfiguredProviderData
+	}
+	rmqc := d.rabbitmqClient
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rmqc.Endpoint+"/api/"+path, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(rmqc.Username, rmqc.Password)
+
+	httpc := &http.Client{Transport: d.transport}
+	res, err := httpc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		rme := rabbithole.ErrorResponse{}
+		if err := json.NewDecoder(res.Body).Decode(&rme); err != nil {
+			rme.Message = res.Status
+		}
+		rme.StatusCode = res.StatusCode
+		return rme
 	}
 
-	return rabbitmqClient, nil
+	return json.NewDecoder(res.Body).Decode(rec)
 }
@@ -0,0 +1,672 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerEnv lists the environment variables read by the provider.
+var providerEnv = []string{
+	"RABBITMQ_ENDPOINT",
+	"RABBITMQ_ENDPOINTS",
+	"RABBITMQ_USERNAME",
+	"RABBITMQ_PASSWORD",
+	"RABBITMQ_INSECURE",
+	"RABBITMQ_SKIP_CONNECTIVITY_CHECK",
+	"RABBITMQ_CACERT",
+	"RABBITMQ_CLIENTCERT",
+	"RABBITMQ_CLIENTKEY",
+	"RABBITMQ_CACERT_PEM",
+	"RABBITMQ_CLIENTCERT_PEM",
+	"RABBITMQ_CLIENTKEY_PEM",
+	"RABBITMQ_PROXY",
+	"RABBITMQ_TOKEN",
+	"RABBITMQ_HEADERS",
+	"RABBITMQ_DEFAULT_VHOST",
+	"RABBITMQ_CONNECT_TIMEOUT",
+	"RABBITMQ_TLS_HANDSHAKE_TIMEOUT",
+	"RABBITMQ_REQUEST_TIMEOUT",
+	"RABBITMQ_MAX_CONCURRENT_REQUESTS",
+	"RABBITMQ_REQUESTS_PER_SECOND",
+}
+
+// setProviderEnv clears the provider environment variables for the test and
+// then sets env.
+func setProviderEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for _, name := range providerEnv {
+		t.Setenv(name, "")
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
+
+// newProviderModel returns a provider configuration with every attribute null.
+func newProviderModel() RabbitmqProviderModel {
+	return RabbitmqProviderModel{
+		Endpoints: types.ListNull(types.StringType),
+		Headers:   types.MapNull(types.StringType),
+	}
+}
+
+// configureProvider runs Configure with model as the provider configuration.
+func configureProvider(t *testing.T, model RabbitmqProviderModel) *provider.ConfigureResponse {
+	t.Helper()
+
+	p := &RabbitmqProvider{}
+	var schemaResp provider.SchemaResponse
+	p.Schema(t.Context(), provider.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := config.Set(t.Context(), &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(t.Context(), provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+	}, resp)
+	return resp
+}
+
+func TestConfigureUnknownValue(t *testing.T) {
+	setProviderEnv(t, nil)
+
+	model := newProviderModel()
+	model.Address = types.StringUnknown()
+	model.Username = types.StringValue("guest")
+	model.Password = types.StringValue("guest")
+
+	resp := configureProvider(t, model)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("diagnostics = %v, want none", resp.Diagnostics)
+	}
+	if resp.ResourceData != nil || resp.DataSourceData != nil {
+		t.Error("provider configured from an unknown address")
+	}
+}
+
+func TestUnconfiguredProviderData(t *testing.T) {
+	var d *RabbitmqProviderData
+
+	if _, err := d.client(t.Context()).ListVhosts(); err == nil {
+		t.Error("request of an unconfigured provider succeeded")
+	}
+	var rec map[string]interface{}
+	if err := d.getJSON(t.Context(), "overview", &rec); err == nil {
+		t.Error("request of an unconfigured provider succeeded")
+	}
+}
+
+func TestApplyProviderEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		config func(*RabbitmqProviderModel)
+		// wantErrors holds a substring of the detail of each expected error.
+		wantErrors []string
+		check      func(*testing.T, RabbitmqProviderModel)
+	}{
+		{
+			name: "configuration beats environment",
+			env: map[string]string{
+				"RABBITMQ_ENDPOINT": "http://env:15672",
+				"RABBITMQ_USERNAME": "env-user",
+				"RABBITMQ_PASSWORD": "env-password",
+				"RABBITMQ_INSECURE": "true",
+			},
+			config: func(m *RabbitmqProviderModel) {
+				m.Address = types.StringValue("http://hcl:15672")
+				m.Username = types.StringValue("hcl-user")
+				m.Insecure = types.BoolValue(false)
+			},
+			check: func(t *testing.T, m RabbitmqProviderModel) {
+				if m.Address.ValueString() != "http://hcl:15672" || m.Username.ValueString() != "hcl-user" || m.Insecure.ValueBool() {
+					t.Errorf("address, username, insecure = %s, %s, %s, want the configured values", m.Address, m.Username, m.Insecure)
+				}
+				if m.Password.ValueString() != "env-password" {
+					t.Errorf("password = %s, want it from the environment", m.Password)
+				}
+			},
+		},
+		{
+			name: "environment fills unset values",
+			env: map[string]string{
+				"RABBITMQ_ENDPOINTS": "http://a:15672, http://b:15672",
+				"RABBITMQ_USERNAME":  "guest",
+				"RABBITMQ_PASSWORD":  "guest",
+				"RABBITMQ_INSECURE":  "true",
+			},
+			check: func(t *testing.T, m RabbitmqProviderModel) {
+				if n := len(m.Endpoints.Elements()); n != 2 {
+					t.Errorf("endpoints = %s, want 2 endpoints", m.Endpoints)
+				}
+				if !m.Insecure.ValueBool() {
+					t.Errorf("insecure = %s, want true", m.Insecure)
+				}
+			},
+		},
+		{
+			name: "username and password required for basic auth",
+			env:  map[string]string{"RABBITMQ_ENDPOINT": "http://localhost:15672"},
+			wantErrors: []string{
+				"username is not set. Set username in the provider configuration or the RABBITMQ_USERNAME environment variable.",
+				"password is not set. Set password in the provider configuration or the RABBITMQ_PASSWORD environment variable.",
+			},
+		},
+		{
+			name: "token replaces username and password",
+			env: map[string]string{
+				"RABBITMQ_ENDPOINT": "http://localhost:15672",
+				"RABBITMQ_TOKEN":    "abc",
+			},
+		},
+		{
+			name: "oauth2 replaces username and password",
+			env:  map[string]string{"RABBITMQ_ENDPOINT": "http://localhost:15672"},
+			config: func(m *RabbitmqProviderModel) {
+				m.OAuth2 = &RabbitmqProviderOAuth2Model{
+					TokenURL:     types.StringValue("http://localhost:8080/token"),
+					ClientID:     types.StringValue("terraform"),
+					ClientSecret: types.StringValue("s3cret"),
+					Scopes:       types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name: "token conflicts with oauth2",
+			env: map[string]string{
+				"RABBITMQ_ENDPOINT": "http://localhost:15672",
+				"RABBITMQ_TOKEN":    "abc",
+			},
+			config: func(m *RabbitmqProviderModel) {
+				m.OAuth2 = &RabbitmqProviderOAuth2Model{Scopes: types.ListNull(types.StringType)}
+			},
+			wantErrors: []string{"token and oauth2 cannot both be set"},
+		},
+		{
+			name: "missing address",
+			env: map[string]string{
+				"RABBITMQ_USERNAME": "guest",
+				"RABBITMQ_PASSWORD": "guest",
+			},
+			wantErrors: []string{
+				"Set address or endpoints in the provider configuration, or the RABBITMQ_ENDPOINT or RABBITMQ_ENDPOINTS environment variable.",
+			},
+		},
+		{
+			name: "address conflicts with endpoints",
+			env: map[string]string{
+				"RABBITMQ_USERNAME": "guest",
+				"RABBITMQ_PASSWORD": "guest",
+			},
+			config: func(m *RabbitmqProviderModel) {
+				m.Address = types.StringValue("http://a:15672")
+				m.Endpoints, _ = types.ListValueFrom(t.Context(), types.StringType, []string{"http://b:15672"})
+			},
+			wantErrors: []string{"address and endpoints cannot both be set"},
+		},
+		{
+			name: "empty or unknown endpoint",
+			env: map[string]string{
+				"RABBITMQ_USERNAME": "guest",
+				"RABBITMQ_PASSWORD": "guest",
+			},
+			config: func(m *RabbitmqProviderModel) {
+				m.Endpoints = types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("http://a:15672"),
+					types.StringValue(""),
+					types.StringUnknown(),
+				})
+			},
+			wantErrors: []string{
+				"element 1 is empty or unknown until apply",
+				"element 2 is empty or unknown until apply",
+			},
+		},
+		{
+			name: "invalid RABBITMQ_INSECURE",
+			env: map[string]string{
+				"RABBITMQ_ENDPOINT": "http://localhost:15672",
+				"RABBITMQ_USERNAME": "guest",
+				"RABBITMQ_PASSWORD": "guest",
+				"RABBITMQ_INSECURE": "maybe",
+			},
+			wantErrors: []string{`The RABBITMQ_INSECURE environment variable must be true or false, got "maybe".`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setProviderEnv(t, tt.env)
+
+			data := newProviderModel()
+			if tt.config != nil {
+				tt.config(&data)
+			}
+
+			diags := applyProviderEnv(&data)
+			if n := diags.ErrorsCount(); n != len(tt.wantErrors) {
+				t.Fatalf("got %d errors, want %d: %v", n, len(tt.wantErrors), diags)
+			}
+			for i, d := range diags.Errors() {
+				if !strings.Contains(d.Detail(), tt.wantErrors[i]) {
+					t.Errorf("error %d = %q, want it to contain %q", i, d.Detail(), tt.wantErrors[i])
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, data)
+			}
+		})
+	}
+}
+
+// newTestCertificate returns a self-signed certificate and its key, PEM
+// encoded.
+func newTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rabbitmq-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(keyPEM)
+}
+
+func TestConfigureRmqClientTLS(t *testing.T) {
+	cert, key := newTestCertificate(t)
+
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(certFile, []byte(cert), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  func(*RabbitmqProviderModel)
+		wantErr string
+	}{
+		{
+			name: "inline PEM",
+			config: func(m *RabbitmqProviderModel) {
+				m.CacertPem = types.StringValue(cert)
+				m.ClientcertPem = types.StringValue(cert)
+				m.ClientkeyPem = types.StringValue(key)
+			},
+		},
+		{
+			name: "files and inline PEM mixed",
+			config: func(m *RabbitmqProviderModel) {
+				m.CacertFile = types.StringValue(certFile)
+				m.ClientcertFile = types.StringValue(certFile)
+				m.ClientkeyPem = types.StringValue(key)
+			},
+		},
+		{
+			name: "CA without certificates",
+			config: func(m *RabbitmqProviderModel) {
+				m.CacertPem = types.StringValue("not a certificate")
+			},
+			wantErr: "cacert_pem contains no PEM encoded certificates",
+		},
+		{
+			name: "client certificate without key",
+			config: func(m *RabbitmqProviderModel) {
+				m.ClientcertPem = types.StringValue(cert)
+			},
+			wantErr: "a client certificate and a client key must be set together, only clientcert_pem is set",
+		},
+		{
+			name: "client key without certificate",
+			config: func(m *RabbitmqProviderModel) {
+				m.ClientkeyPem = types.StringValue(key)
+			},
+			wantErr: "a client certificate and a client key must be set together, only clientkey_pem is set",
+		},
+		{
+			name: "unreadable file",
+			config: func(m *RabbitmqProviderModel) {
+				m.CacertFile = types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))
+			},
+			wantErr: "could not read cacert_file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newProviderModel()
+			model.Address = types.StringValue("https://localhost:15671")
+			model.Username = types.StringValue("guest")
+			model.Password = types.StringValue("guest")
+			tt.config(&model)
+
+			_, _, err := configureRmqClient(&model)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyProviderEnvPEMConflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		config func(*RabbitmqProviderModel)
+	}{
+		{
+			name: "both configured",
+			config: func(m *RabbitmqProviderModel) {
+				m.CacertFile = types.StringValue("/etc/rabbitmq/ca.pem")
+				m.CacertPem = types.StringValue("-----BEGIN CERTIFICATE-----")
+			},
+		},
+		{
+			name: "both from the environment",
+			env: map[string]string{
+				"RABBITMQ_CACERT":     "/etc/rabbitmq/ca.pem",
+				"RABBITMQ_CACERT_PEM": "-----BEGIN CERTIFICATE-----",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{
+				"RABBITMQ_ENDPOINT": "https://localhost:15671",
+				"RABBITMQ_USERNAME": "guest",
+				"RABBITMQ_PASSWORD": "guest",
+			}
+			for name, value := range tt.env {
+				env[name] = value
+			}
+			setProviderEnv(t, env)
+
+			data := newProviderModel()
+			if tt.config != nil {
+				tt.config(&data)
+			}
+
+			diags := applyProviderEnv(&data)
+			if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), "cacert_file and cacert_pem cannot both be set") {
+				t.Errorf("diagnostics = %v, want one conflict", diags)
+			}
+		})
+	}
+
+	t.Run("configured PEM ignores the file from the environment", func(t *testing.T) {
+		setProviderEnv(t, map[string]string{
+			"RABBITMQ_ENDPOINT": "https://localhost:15671",
+			"RABBITMQ_USERNAME": "guest",
+			"RABBITMQ_PASSWORD": "guest",
+			"RABBITMQ_CACERT":   "/etc/rabbitmq/ca.pem",
+		})
+
+		data := newProviderModel()
+		data.CacertPem = types.StringValue("-----BEGIN CERTIFICATE-----")
+		if diags := applyProviderEnv(&data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if !data.CacertFile.IsNull() {
+			t.Errorf("cacert_file = %s, want null", data.CacertFile)
+		}
+	})
+}
+
+func TestConfigureConnectivityCheck(t *testing.T) {
+	var requests atomic.Int32
+	var status atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if code := int(status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			fmt.Fprint(w, `{"error":"not_authorized","reason":"Login failed"}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/whoami":
+			fmt.Fprint(w, `{"name":"guest","tags":["administrator"]}`)
+		case "/api/overview":
+			fmt.Fprint(w, `{"rabbitmq_version":"4.1.2","management_version":"4.1.2","node":"rabbit@localhost"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name         string
+		status       int
+		skip         bool
+		wantRequests int32
+		wantErr      bool
+		wantVersion  string
+	}{
+		{name: "connected", status: http.StatusOK, wantRequests: 2, wantVersion: "4.1.2"},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantRequests: 1, wantErr: true},
+		{name: "skipped", status: http.StatusUnauthorized, skip: true, wantRequests: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setProviderEnv(t, nil)
+			requests.Store(0)
+			status.Store(int32(tt.status))
+
+			model := newProviderModel()
+			model.Address = types.StringValue(srv.URL)
+			model.Username = types.StringValue("guest")
+			model.Password = types.StringValue("wrong")
+			if tt.skip {
+				model.SkipConnectivityCheck = types.BoolValue(true)
+			}
+
+			resp := configureProvider(t, model)
+			if n := requests.Load(); n != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", n, tt.wantRequests)
+			}
+
+			if tt.wantErr {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unable to Connect to RabbitMQ" {
+					t.Fatalf("diagnostics = %v, want one Unable to Connect to RabbitMQ error", resp.Diagnostics)
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, srv.URL) || !strings.Contains(detail, "401") {
+					t.Errorf("detail = %q, want the endpoint and the 401", detail)
+				}
+				if resp.ResourceData != nil {
+					t.Error("provider configured despite the failed check")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			providerData, ok := resp.ResourceData.(*RabbitmqProviderData)
+			if !ok {
+				t.Fatalf("resource data = %#v, want the provider data", resp.ResourceData)
+			}
+			if providerData.rabbitmqVersion != tt.wantVersion {
+				t.Errorf("rabbitmq version = %q, want %q", providerData.rabbitmqVersion, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestPlanVhost(t *testing.T) {
+	r := &RabbitmqPermissionsResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(t.Context(), resource.SchemaRequest{}, &schemaResp)
+
+	// state returns the permissions resource for vhost, or no resource when
+	// vhost is nil.
+	state := func(vhost *types.String) tfsdk.State {
+		t.Helper()
+		s := tfsdk.State{Schema: schemaResp.Schema}
+		if vhost == nil {
+			return s
+		}
+		if diags := s.Set(t.Context(), &RabbitmqPermissionsResourceModel{
+			User:      types.StringValue("app"),
+			Vhost:     *vhost,
+			Configure: types.StringValue(".*"),
+			Write:     types.StringValue(".*"),
+			Read:      types.StringValue(".*"),
+			Id:        types.StringUnknown(),
+		}); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return s
+	}
+	value := func(v types.String) *types.String { return &v }
+
+	configured := &RabbitmqProviderData{defaultVhost: "tenant"}
+	tests := []struct {
+		name         string
+		providerData *RabbitmqProviderData
+		config       *types.String
+		state        *types.String
+		wantVhost    types.String
+		wantReplace  bool
+	}{
+		{
+			name:         "create with the default vhost",
+			providerData: configured,
+			config:       value(types.StringNull()),
+			wantVhost:    types.StringValue("tenant"),
+		},
+		{
+			name:         "create with a configured vhost",
+			providerData: configured,
+			config:       value(types.StringValue("other")),
+			wantVhost:    types.StringValue("other"),
+		},
+		{
+			name:         "unchanged default vhost",
+			providerData: configured,
+			config:       value(types.StringNull()),
+			state:        value(types.StringValue("tenant")),
+			wantVhost:    types.StringValue("tenant"),
+		},
+		{
+			name:         "changed default vhost",
+			providerData: configured,
+			config:       value(types.StringNull()),
+			state:        value(types.StringValue("/")),
+			wantVhost:    types.StringValue("tenant"),
+			wantReplace:  true,
+		},
+		{
+			name:         "changed configured vhost",
+			providerData: configured,
+			config:       value(types.StringValue("other")),
+			state:        value(types.StringValue("tenant")),
+			wantVhost:    types.StringValue("other"),
+			wantReplace:  true,
+		},
+		{
+			name:      "unconfigured provider",
+			config:    value(types.StringNull()),
+			wantVhost: types.StringUnknown(),
+		},
+		{
+			name:        "unconfigured provider with prior state",
+			config:      value(types.StringNull()),
+			state:       value(types.StringValue("/")),
+			wantVhost:   types.StringUnknown(),
+			wantReplace: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// As for every Optional and Computed attribute, a vhost left
+			// unset is unknown in the proposed plan.
+			proposed := *tt.config
+			if proposed.IsNull() {
+				proposed = types.StringUnknown()
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state(tt.config).Raw},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: state(&proposed).Raw},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: state(tt.state).Raw},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			tt.providerData.planVhost(t.Context(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var vhost types.String
+			resp.Plan.GetAttribute(t.Context(), path.Root("vhost"), &vhost)
+			if !vhost.Equal(tt.wantVhost) {
+				t.Errorf("planned vhost = %s, want %s", vhost, tt.wantVhost)
+			}
+			if replace := len(resp.RequiresReplace) > 0; replace != tt.wantReplace {
+				t.Errorf("requires replace = %v, want %v", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+
+	t.Run("destroy", func(t *testing.T) {
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state(nil).Raw},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: state(nil).Raw},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: state(value(types.StringValue("/"))).Raw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		configured.planVhost(t.Context(), req, resp)
+		if resp.Diagnostics.HasError() || len(resp.RequiresReplace) > 0 || !resp.Plan.Raw.IsNull() {
+			t.Errorf("destroy plan changed: %v, %v", resp.Diagnostics, resp.RequiresReplace)
+		}
+	})
+}
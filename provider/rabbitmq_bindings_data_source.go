@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqBindingsDataSource{}
+
+func NewRabbitmqBindingsDataSource() datasource.DataSource {
+	return &RabbitmqBindingsDataSource{}
+}
+
+type RabbitmqBindingsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqBindingsBindingModel struct {
+	Vhost           types.String `tfsdk:"vhost"`
+	Source          types.String `tfsdk:"source"`
+	Destination     types.String `tfsdk:"destination"`
+	DestinationType types.String `tfsdk:"destination_type"`
+	RoutingKey      types.String `tfsdk:"routing_key"`
+	Arguments       types.Map    `tfsdk:"arguments"`
+	PropertiesKey   types.String `tfsdk:"properties_key"`
+}
+
+type RabbitmqBindingsDataSourceModel struct {
+	Vhost           types.String                   `tfsdk:"vhost"`
+	Source          types.String                   `tfsdk:"source"`
+	Destination     types.String                   `tfsdk:"destination"`
+	DestinationType types.String                   `tfsdk:"destination_type"`
+	RoutingKeyRegex types.String                   `tfsdk:"routing_key_regex"`
+	Bindings        []RabbitmqBindingsBindingModel `tfsdk:"bindings"`
+}
+
+func (d *RabbitmqBindingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqBindingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bindings"
+}
+
+func (d *RabbitmqBindingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list bindings in this vhost. All vhosts visible to the provider user are listed when unset.",
+			},
+			"source": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list bindings whose source is this exchange.",
+			},
+			"destination": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list bindings whose destination is this queue or exchange.",
+			},
+			"destination_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list bindings whose destination is of this type, either queue or exchange.",
+			},
+			"routing_key_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list bindings whose routing key matches this regular expression.",
+			},
+			"bindings": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching bindings.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the binding.",
+						},
+						"source": schema.StringAttribute{
+							Computed:    true,
+							Description: "The source exchange. Empty for the default exchange.",
+						},
+						"destination": schema.StringAttribute{
+							Computed:    true,
+							Description: "The destination queue or exchange.",
+						},
+						"destination_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The destination type, either queue or exchange.",
+						},
+						"routing_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The routing key of the binding.",
+						},
+						"arguments": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The binding arguments. Values that are not strings are JSON encoded.",
+						},
+						"properties_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key identifying the binding between its source and destination.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqBindingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqBindingsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+	source := data.Source.ValueString()
+	destination := data.Destination.ValueString()
+	destinationType := data.DestinationType.ValueString()
+
+	if destinationType != "" && destinationType != "queue" && destinationType != "exchange" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destination_type"),
+			"Invalid Destination Type",
+			fmt.Sprintf("destination_type must be either queue or exchange, got %q.", destinationType),
+		)
+		return
+	}
+
+	var routingKeyRegex *regexp.Regexp
+	if !data.RoutingKeyRegex.IsNull() {
+		var err error
+		routingKeyRegex, err = regexp.Compile(data.RoutingKeyRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("routing_key_regex"),
+				"Invalid Routing Key Regex",
+				fmt.Sprintf("Could not compile routing_key_regex: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq bindings", map[string]interface{}{
+		"vhost":            vhost,
+		"source":           source,
+		"destination":      destination,
+		"destination_type": destinationType,
+	})
+
+	bindings, err := d.listBindings(ctx, vhost, source, destination, destinationType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Bindings",
+			fmt.Sprintf("Could not list RabbitMQ bindings: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Bindings = []RabbitmqBindingsBindingModel{}
+	for _, b := range bindings {
+		// The narrowest endpoint is not always narrow enough, so every
+		// filter is applied again to the response.
+		if vhost != "" && b.Vhost != vhost {
+			continue
+		}
+		if !data.Source.IsNull() && b.Source != source {
+			continue
+		}
+		if !data.Destination.IsNull() && b.Destination != destination {
+			continue
+		}
+		if destinationType != "" && b.DestinationType != destinationType {
+			continue
+		}
+		if routingKeyRegex != nil && !routingKeyRegex.MatchString(b.RoutingKey) {
+			continue
+		}
+
+		arguments, err := argumentsToMapValue(b.Arguments)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading RabbitMQ Binding Arguments",
+				fmt.Sprintf("Could not read arguments of binding %s -> %s in vhost %s: %s", b.Source, b.Destination, b.Vhost, err.Error()),
+			)
+			return
+		}
+
+		data.Bindings = append(data.Bindings, RabbitmqBindingsBindingModel{
+			Vhost:           types.StringValue(b.Vhost),
+			Source:          types.StringValue(b.Source),
+			Destination:     types.StringValue(b.Destination),
+			DestinationType: types.StringValue(b.DestinationType),
+			RoutingKey:      types.StringValue(b.RoutingKey),
+			Arguments:       arguments,
+			PropertiesKey:   types.StringValue(b.PropertiesKey),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listBindings picks the narrowest /api/bindings endpoint for the given filters.
+func (d *RabbitmqBindingsDataSource) listBindings(ctx context.Context, vhost, source, destination, destinationType string) ([]rabbithole.BindingInfo, error) {
+	rmqc := d.providerData.client(ctx)
+
+	if vhost == "" {
+		return rmqc.ListBindings()
+	}
+
+	switch {
+	case source != "" && destination != "" && destinationType == "queue":
+		return rmqc.ListQueueBindingsBetween(vhost, source, destination)
+	case source != "" && destination != "" && destinationType == "exchange":
+		return rmqc.ListExchangeBindingsBetween(vhost, source, destination)
+	case destination != "" && destinationType == "queue":
+		return rmqc.ListQueueBindings(vhost, destination)
+	case destination != "" && destinationType == "exchange":
+		return rmqc.ListExchangeBindingsWithDestination(vhost, destination)
+	case source != "":
+		return rmqc.ListExchangeBindingsWithSource(vhost, source)
+	}
+
+	return rmqc.ListBindingsIn(vhost)
+}
+
+// argumentsToMapValue converts AMQP arguments to a map of strings. Values
+// that are not strings are JSON encoded.
+func argumentsToMapValue(arguments map[string]interface{}) (types.Map, error) {
+	elements := make(map[string]attr.Value, len(arguments))
+	for k, v := range arguments {
+		if s, ok := v.(string); ok {
+			elements[k] = types.StringValue(s)
+			continue
+		}
+
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return types.MapNull(types.StringType), err
+		}
+		elements[k] = types.StringValue(string(encoded))
+	}
+
+	return types.MapValueMust(types.StringType, elements), nil
+}
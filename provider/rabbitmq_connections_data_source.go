@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqConnectionsDataSource{}
+
+func NewRabbitmqConnectionsDataSource() datasource.DataSource {
+	return &RabbitmqConnectionsDataSource{}
+}
+
+type RabbitmqConnectionsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqConnectionModel struct {
+	Name               types.String `tfsdk:"name"`
+	Vhost              types.String `tfsdk:"vhost"`
+	User               types.String `tfsdk:"user"`
+	ClientProvidedName types.String `tfsdk:"client_provided_name"`
+	Protocol           types.String `tfsdk:"protocol"`
+	Node               types.String `tfsdk:"node"`
+	State              types.String `tfsdk:"state"`
+	PeerHost           types.String `tfsdk:"peer_host"`
+	PeerPort           types.Int64  `tfsdk:"peer_port"`
+	Tls                types.Bool   `tfsdk:"tls"`
+	TlsProtocol        types.String `tfsdk:"tls_protocol"`
+	TlsCipher          types.String `tfsdk:"tls_cipher"`
+	PeerCertSubject    types.String `tfsdk:"peer_cert_subject"`
+	PeerCertIssuer     types.String `tfsdk:"peer_cert_issuer"`
+	PeerCertValidity   types.String `tfsdk:"peer_cert_validity"`
+	Channels           types.Int64  `tfsdk:"channels"`
+	ConnectedAt        types.String `tfsdk:"connected_at"`
+}
+
+type RabbitmqConnectionsDataSourceModel struct {
+	Vhost              types.String              `tfsdk:"vhost"`
+	User               types.String              `tfsdk:"user"`
+	ClientProvidedName types.String              `tfsdk:"client_provided_name"`
+	Protocol           types.String              `tfsdk:"protocol"`
+	PageSize           types.Int64               `tfsdk:"page_size"`
+	Connections        []RabbitmqConnectionModel `tfsdk:"connections"`
+}
+
+func (d *RabbitmqConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqConnectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connections"
+}
+
+func (d *RabbitmqConnectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list connections to this vhost. Connections to all vhosts visible to the provider user are listed when unset.",
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list connections authenticated as this user.",
+			},
+			"client_provided_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list connections whose client provided this connection name.",
+			},
+			"protocol": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list connections using this protocol, as reported by the broker, e.g. AMQP 0-9-1.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of connections per request. Defaults to %d, maximum %d.", defaultPageSize, maxPageSize),
+			},
+			"connections": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching connections.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the connection.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost the connection is open to.",
+						},
+						"user": schema.StringAttribute{
+							Computed:    true,
+							Description: "The user the connection is authenticated as.",
+						},
+						"client_provided_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The connection name provided by the client. Empty when the client did not provide one.",
+						},
+						"protocol": schema.StringAttribute{
+							Computed:    true,
+							Description: "The protocol of the connection.",
+						},
+						"node": schema.StringAttribute{
+							Computed:    true,
+							Description: "The node the client is connected to.",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state of the connection, e.g. running or blocked.",
+						},
+						"peer_host": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address of the client.",
+						},
+						"peer_port": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The port of the client.",
+						},
+						"tls": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the connection uses TLS.",
+						},
+						"tls_protocol": schema.StringAttribute{
+							Computed:    true,
+							Description: "The TLS protocol version. Empty without TLS.",
+						},
+						"tls_cipher": schema.StringAttribute{
+							Computed:    true,
+							Description: "The TLS cipher. Empty without TLS.",
+						},
+						"peer_cert_subject": schema.StringAttribute{
+							Computed:    true,
+							Description: "The subject of the client certificate. Empty without one.",
+						},
+						"peer_cert_issuer": schema.StringAttribute{
+							Computed:    true,
+							Description: "The issuer of the client certificate. Empty without one.",
+						},
+						"peer_cert_validity": schema.StringAttribute{
+							Computed:    true,
+							Description: "The validity period of the client certificate. Empty without one.",
+						},
+						"channels": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of open channels.",
+						},
+						"connected_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the connection was opened, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqConnectionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+
+	pageSize := int64(defaultPageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_size"),
+			"Invalid Page Size",
+			fmt.Sprintf("page_size must be between 1 and %d, got %d.", maxPageSize, pageSize),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq connections", map[string]interface{}{
+		"vhost":     vhost,
+		"page_size": pageSize,
+	})
+
+	params := url.Values{}
+	params.Set("page_size", strconv.FormatInt(pageSize, 10))
+
+	rmqc := d.providerData.client(ctx)
+	data.Connections = []RabbitmqConnectionModel{}
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+
+		var paged rabbithole.PagedConnectionInfo
+		var err error
+		if vhost == "" {
+			paged, err = rmqc.PagedListConnectionsWithParameters(params)
+		} else {
+			// rabbit-hole has no paginated variant of the per-vhost endpoint.
+			err = d.providerData.getJSON(ctx, "vhosts/"+url.PathEscape(vhost)+"/connections?"+params.Encode(), &paged)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing RabbitMQ Connections",
+				fmt.Sprintf("Could not list RabbitMQ connections (page %d): %s", page, err.Error()),
+			)
+			return
+		}
+
+		for _, c := range paged.Items {
+			clientProvidedName, _ := c.ClientProperties["connection_name"].(string)
+
+			if !data.User.IsNull() && c.User != data.User.ValueString() {
+				continue
+			}
+			if !data.ClientProvidedName.IsNull() && clientProvidedName != data.ClientProvidedName.ValueString() {
+				continue
+			}
+			if !data.Protocol.IsNull() && c.Protocol != data.Protocol.ValueString() {
+				continue
+			}
+
+			connectedAt := types.StringNull()
+			if c.ConnectedAt != 0 {
+				connectedAt = types.StringValue(time.UnixMilli(int64(c.ConnectedAt)).UTC().Format(time.RFC3339))
+			}
+
+			data.Connections = append(data.Connections, RabbitmqConnectionModel{
+				Name:               types.StringValue(c.Name),
+				Vhost:              types.StringValue(c.Vhost),
+				User:               types.StringValue(c.User),
+				ClientProvidedName: types.StringValue(clientProvidedName),
+				Protocol:           types.StringValue(c.Protocol),
+				Node:               types.StringValue(c.Node),
+				State:              types.StringValue(c.State),
+				PeerHost:           types.StringValue(c.PeerHost),
+				PeerPort:           types.Int64Value(int64(c.PeerPort)),
+				Tls:                types.BoolValue(c.UsesTLS),
+				TlsProtocol:        types.StringValue(c.SSLProtocol),
+				TlsCipher:          types.StringValue(c.SSLCipher),
+				PeerCertSubject:    types.StringValue(c.PeerCertSubject),
+				PeerCertIssuer:     types.StringValue(c.PeerCertIssuer),
+				PeerCertValidity:   types.StringValue(c.PeerCertValidity),
+				Channels:           types.Int64Value(int64(c.Channels)),
+				ConnectedAt:        connectedAt,
+			})
+		}
+
+		if page >= paged.PageCount {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
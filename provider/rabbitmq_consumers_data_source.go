@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqConsumersDataSource{}
+
+func NewRabbitmqConsumersDataSource() datasource.DataSource {
+	return &RabbitmqConsumersDataSource{}
+}
+
+type RabbitmqConsumersDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqConsumerChannelModel struct {
+	Name           types.String `tfsdk:"name"`
+	Number         types.Int64  `tfsdk:"number"`
+	ConnectionName types.String `tfsdk:"connection_name"`
+	Node           types.String `tfsdk:"node"`
+	PeerHost       types.String `tfsdk:"peer_host"`
+	PeerPort       types.Int64  `tfsdk:"peer_port"`
+	User           types.String `tfsdk:"user"`
+}
+
+type RabbitmqConsumerModel struct {
+	ConsumerTag    types.String                 `tfsdk:"consumer_tag"`
+	Queue          types.String                 `tfsdk:"queue"`
+	Vhost          types.String                 `tfsdk:"vhost"`
+	ChannelDetails RabbitmqConsumerChannelModel `tfsdk:"channel_details"`
+	PrefetchCount  types.Int64                  `tfsdk:"prefetch_count"`
+	AckRequired    types.Bool                   `tfsdk:"ack_required"`
+	Exclusive      types.Bool                   `tfsdk:"exclusive"`
+	Arguments      types.Map                    `tfsdk:"arguments"`
+}
+
+type RabbitmqConsumersDataSourceModel struct {
+	Vhost         types.String            `tfsdk:"vhost"`
+	Queue         types.String            `tfsdk:"queue"`
+	ConsumerCount types.Int64             `tfsdk:"consumer_count"`
+	Consumers     []RabbitmqConsumerModel `tfsdk:"consumers"`
+}
+
+func (d *RabbitmqConsumersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqConsumersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consumers"
+}
+
+func (d *RabbitmqConsumersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list consumers in this vhost. Consumers in all vhosts visible to the provider user are listed when unset.",
+			},
+			"queue": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list consumers of this queue.",
+			},
+			"consumer_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of matching consumers.",
+			},
+			"consumers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching consumers.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"consumer_tag": schema.StringAttribute{
+							Computed:    true,
+							Description: "The consumer tag.",
+						},
+						"queue": schema.StringAttribute{
+							Computed:    true,
+							Description: "The queue the consumer is subscribed to.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the queue.",
+						},
+						"channel_details": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The channel the consumer is registered on.",
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Computed:    true,
+									Description: "The name of the channel.",
+								},
+								"number": schema.Int64Attribute{
+									Computed:    true,
+									Description: "The number of the channel on its connection.",
+								},
+								"connection_name": schema.StringAttribute{
+									Computed:    true,
+									Description: "The name of the connection the channel belongs to.",
+								},
+								"node": schema.StringAttribute{
+									Computed:    true,
+									Description: "The node the connection is open to.",
+								},
+								"peer_host": schema.StringAttribute{
+									Computed:    true,
+									Description: "The address of the client.",
+								},
+								"peer_port": schema.Int64Attribute{
+									Computed:    true,
+									Description: "The port of the client.",
+								},
+								"user": schema.StringAttribute{
+									Computed:    true,
+									Description: "The user the connection is authenticated as.",
+								},
+							},
+						},
+						"prefetch_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The prefetch count of the consumer. 0 means unlimited.",
+						},
+						"ack_required": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether deliveries to the consumer must be acknowledged.",
+						},
+						"exclusive": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the consumer is exclusive.",
+						},
+						"arguments": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The consumer arguments. Values that are not strings are JSON encoded.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqConsumersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqConsumersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+
+	tflog.Trace(ctx, "listing rabbitmq consumers", map[string]interface{}{
+		"vhost": vhost,
+		"queue": data.Queue.ValueString(),
+	})
+
+	rmqc := d.providerData.client(ctx)
+	var consumers []rabbithole.ConsumerInfo
+	var err error
+	if vhost == "" {
+		consumers, err = rmqc.ListConsumers()
+	} else {
+		consumers, err = rmqc.ListConsumersIn(vhost)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Consumers",
+			fmt.Sprintf("Could not list RabbitMQ consumers: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Consumers = []RabbitmqConsumerModel{}
+	for _, c := range consumers {
+		if !data.Queue.IsNull() && c.Queue.Name != data.Queue.ValueString() {
+			continue
+		}
+
+		arguments, err := argumentsToMapValue(c.Arguments)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading RabbitMQ Consumer Arguments",
+				fmt.Sprintf("Could not read arguments of consumer %s on queue %s in vhost %s: %s", c.ConsumerTag, c.Queue.Name, c.Queue.Vhost, err.Error()),
+			)
+			return
+		}
+
+		data.Consumers = append(data.Consumers, RabbitmqConsumerModel{
+			ConsumerTag: types.StringValue(c.ConsumerTag),
+			Queue:       types.StringValue(c.Queue.Name),
+			Vhost:       types.StringValue(c.Queue.Vhost),
+			ChannelDetails: RabbitmqConsumerChannelModel{
+				Name:           types.StringValue(c.ChannelDetails.Name),
+				Number:         types.Int64Value(int64(c.ChannelDetails.Number)),
+				ConnectionName: types.StringValue(c.ChannelDetails.ConnectionName),
+				Node:           types.StringValue(c.ChannelDetails.Node),
+				PeerHost:       types.StringValue(c.ChannelDetails.PeerHost),
+				PeerPort:       types.Int64Value(int64(c.ChannelDetails.PeerPort)),
+				User:           types.StringValue(c.ChannelDetails.User),
+			},
+			PrefetchCount: types.Int64Value(int64(c.PrefetchCount)),
+			AckRequired:   types.BoolValue(bool(c.AcknowledgementMode)),
+			Exclusive:     types.BoolValue(c.Exclusive),
+			Arguments:     arguments,
+		})
+	}
+
+	data.ConsumerCount = types.Int64Value(int64(len(data.Consumers)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqDefinitionsDataSource{}
+
+// definitionKinds lists the object kinds of an export. Every other top-level
+// key, such as rabbitmq_version, is metadata and always kept.
+var definitionKinds = []string{
+	"users",
+	"vhosts",
+	"permissions",
+	"topic_permissions",
+	"parameters",
+	"global_parameters",
+	"policies",
+	"queues",
+	"exchanges",
+	"bindings",
+}
+
+// definitionSortFields are the fields that identify an object in an export,
+// in the order they are compared when sorting a kind.
+var definitionSortFields = []string{
+	"vhost",
+	"component",
+	"name",
+	"user",
+	"source",
+	"destination_type",
+	"destination",
+	"routing_key",
+	"exchange",
+}
+
+func NewRabbitmqDefinitionsDataSource() datasource.DataSource {
+	return &RabbitmqDefinitionsDataSource{}
+}
+
+type RabbitmqDefinitionsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqDefinitionsDataSourceModel struct {
+	Vhost                 types.String `tfsdk:"vhost"`
+	Kinds                 types.List   `tfsdk:"kinds"`
+	ExcludeUsers          types.Bool   `tfsdk:"exclude_users"`
+	ExcludePasswordHashes types.Bool   `tfsdk:"exclude_password_hashes"`
+	Json                  types.String `tfsdk:"json"`
+}
+
+func (d *RabbitmqDefinitionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqDefinitionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_definitions"
+}
+
+func (d *RabbitmqDefinitionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only export the definitions of this vhost. The whole cluster is exported when unset.",
+			},
+			"kinds": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: fmt.Sprintf("Only export these object kinds. All kinds are exported when unset. Valid values: %s.", strings.Join(definitionKinds, ", ")),
+			},
+			"exclude_users": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Leave users out of the export.",
+			},
+			"exclude_password_hashes": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Remove password hashes and hashing algorithms from exported users.",
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The exported definitions as indented JSON, with sorted keys and each object kind sorted by the fields that identify its objects. Sensitive, as it holds password hashes unless exclude_password_hashes is set.",
+			},
+		},
+	}
+}
+
+func (d *RabbitmqDefinitionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqDefinitionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var kinds []string
+	if !data.Kinds.IsNull() {
+		resp.Diagnostics.Append(data.Kinds.ElementsAs(ctx, &kinds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, k := range kinds {
+			if !slices.Contains(definitionKinds, k) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("kinds"),
+					"Invalid Definition Kind",
+					fmt.Sprintf("Unknown definition kind %q. Valid values: %s.", k, strings.Join(definitionKinds, ", ")),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	vhost := data.Vhost.ValueString()
+	apiPath := "definitions"
+	if vhost != "" {
+		apiPath = "definitions/" + url.PathEscape(vhost)
+	}
+
+	tflog.Trace(ctx, "exporting rabbitmq definitions", map[string]interface{}{
+		"vhost": vhost,
+	})
+
+	var raw json.RawMessage
+	if err := d.providerData.getJSON(ctx, apiPath, &raw); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Exporting RabbitMQ Definitions",
+			fmt.Sprintf("Could not export RabbitMQ definitions: %s", err.Error()),
+		)
+		return
+	}
+
+	// Decoding with UseNumber keeps numbers exactly as the broker sent them.
+	var definitions map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&definitions); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Exporting RabbitMQ Definitions",
+			fmt.Sprintf("Could not decode RabbitMQ definitions: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, kind := range definitionKinds {
+		if kinds != nil && !slices.Contains(kinds, kind) {
+			delete(definitions, kind)
+		}
+	}
+	if data.ExcludeUsers.ValueBool() {
+		delete(definitions, "users")
+	}
+	if data.ExcludePasswordHashes.ValueBool() {
+		if users, ok := definitions["users"].([]interface{}); ok {
+			for _, u := range users {
+				if user, ok := u.(map[string]interface{}); ok {
+					delete(user, "password_hash")
+					delete(user, "hashing_algorithm")
+				}
+			}
+		}
+	}
+
+	for _, kind := range definitionKinds {
+		if objects, ok := definitions[kind].([]interface{}); ok {
+			sortDefinitionObjects(objects)
+		}
+	}
+
+	// encoding/json writes map keys in sorted order.
+	encoded, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Exporting RabbitMQ Definitions",
+			fmt.Sprintf("Could not encode RabbitMQ definitions: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Json = types.StringValue(string(encoded) + "\n")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sortDefinitionObjects sorts the objects of one kind by their identifying
+// fields, falling back to their full encoding so the order is always stable.
+func sortDefinitionObjects(objects []interface{}) {
+	type keyed struct {
+		key    string
+		object interface{}
+	}
+
+	sorted := make([]keyed, len(objects))
+	for i, o := range objects {
+		var parts []string
+		if object, ok := o.(map[string]interface{}); ok {
+			for _, field := range definitionSortFields {
+				parts = append(parts, fmt.Sprint(object[field]))
+			}
+		}
+		encoded, _ := json.Marshal(o)
+		parts = append(parts, string(encoded))
+		sorted[i] = keyed{key: strings.Join(parts, "\x00"), object: o}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+
+	for i, k := range sorted {
+		objects[i] = k.object
+	}
+}
@@ -20,6 +20,7 @@ import (
 )
 
 var _ resource.Resource = &RabbitmqExchangeResource{}
+var _ resource.ResourceWithModifyPlan = &RabbitmqExchangeResource{}
 
 func NewRabbitmqExchangeResource() resource.Resource {
 	return &RabbitmqExchangeResource{}
@@ -66,9 +67,6 @@ func (r *RabbitmqExchangeResource) Schema(ctx context.Context, req resource.Sche
 			"vhost": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"settings": schema.SingleNestedAttribute{
 				Required: true,
@@ -131,6 +129,10 @@ func (r *RabbitmqExchangeResource) ImportState(ctx context.Context, req resource
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
+func (r *RabbitmqExchangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planVhost(ctx, req, resp)
+}
+
 func (r *RabbitmqExchangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan RabbitmqExchangeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -139,8 +141,8 @@ func (r *RabbitmqExchangeResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	name := plan.Name.ValueString()
-	vhost := "/"
-	if !plan.Vhost.IsNull() {
+	vhost := r.providerData.defaultVhost
+	if !plan.Vhost.IsNull() && !plan.Vhost.IsUnknown() {
 		vhost = plan.Vhost.ValueString()
 	}
 
@@ -179,7 +181,7 @@ func (r *RabbitmqExchangeResource) Create(ctx context.Context, req resource.Crea
 		Arguments:  arguments,
 	}
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	response, err := rmqc.DeclareExchange(vhost, name, exchangeSettings)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -215,7 +217,7 @@ func (r *RabbitmqExchangeResource) Read(ctx context.Context, req resource.ReadRe
 		"vhost": vhost,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	exchange, err := rmqc.GetExchange(vhost, name)
 	if err != nil {
 		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
@@ -286,7 +288,7 @@ func (r *RabbitmqExchangeResource) Delete(ctx context.Context, req resource.Dele
 		"vhost": vhost,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	response, err := rmqc.DeleteExchange(vhost, name)
 	if err != nil {
 		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
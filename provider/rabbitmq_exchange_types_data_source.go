@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqExchangeTypesDataSource{}
+
+func NewRabbitmqExchangeTypesDataSource() datasource.DataSource {
+	return &RabbitmqExchangeTypesDataSource{}
+}
+
+type RabbitmqExchangeTypesDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqExchangeTypeModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+type RabbitmqExchangeTypesDataSourceModel struct {
+	ExchangeTypes  []RabbitmqExchangeTypeModel `tfsdk:"exchange_types"`
+	Names          types.List                  `tfsdk:"names"`
+	EnabledPlugins types.List                  `tfsdk:"enabled_plugins"`
+}
+
+// rabbitmqNodePlugins is the part of a /api/nodes entry that rabbit-hole's
+// NodeInfo does not decode.
+type rabbitmqNodePlugins struct {
+	Name           string   `json:"name"`
+	EnabledPlugins []string `json:"enabled_plugins"`
+}
+
+func (d *RabbitmqExchangeTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqExchangeTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exchange_types"
+}
+
+func (d *RabbitmqExchangeTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"exchange_types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The exchange types the cluster supports, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the exchange type, as used in rabbitmq_exchange settings.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the exchange type.",
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the exchange types the cluster supports, sorted.",
+			},
+			"enabled_plugins": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The plugins enabled on any node of the cluster, sorted.",
+			},
+		},
+	}
+}
+
+func (d *RabbitmqExchangeTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqExchangeTypesDataSourceModel
+
+	tflog.Trace(ctx, "reading rabbitmq exchange types")
+
+	overview, err := d.providerData.client(ctx).Overview()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RabbitMQ Overview",
+			fmt.Sprintf("Could not read the RabbitMQ overview: %s", err.Error()),
+		)
+		return
+	}
+
+	var nodes []rabbitmqNodePlugins
+	if err := d.providerData.getJSON(ctx, "nodes", &nodes); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Nodes",
+			fmt.Sprintf("Could not list RabbitMQ nodes: %s", err.Error()),
+		)
+		return
+	}
+
+	exchangeTypes := overview.ExchangeTypes
+	sort.Slice(exchangeTypes, func(i, j int) bool {
+		return exchangeTypes[i].Name < exchangeTypes[j].Name
+	})
+
+	data.ExchangeTypes = make([]RabbitmqExchangeTypeModel, 0, len(exchangeTypes))
+	names := make([]attr.Value, 0, len(exchangeTypes))
+	for _, t := range exchangeTypes {
+		data.ExchangeTypes = append(data.ExchangeTypes, RabbitmqExchangeTypeModel{
+			Name:        types.StringValue(t.Name),
+			Description: types.StringValue(t.Description),
+		})
+		names = append(names, types.StringValue(t.Name))
+	}
+	data.Names = types.ListValueMust(types.StringType, names)
+
+	enabled := map[string]bool{}
+	for _, n := range nodes {
+		for _, p := range n.EnabledPlugins {
+			enabled[p] = true
+		}
+	}
+	pluginNames := make([]string, 0, len(enabled))
+	for p := range enabled {
+		pluginNames = append(pluginNames, p)
+	}
+	sort.Strings(pluginNames)
+
+	plugins := make([]attr.Value, 0, len(pluginNames))
+	for _, p := range pluginNames {
+		plugins = append(plugins, types.StringValue(p))
+	}
+	data.EnabledPlugins = types.ListValueMust(types.StringType, plugins)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
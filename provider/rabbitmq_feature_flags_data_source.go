@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqFeatureFlagsDataSource{}
+
+func NewRabbitmqFeatureFlagsDataSource() datasource.DataSource {
+	return &RabbitmqFeatureFlagsDataSource{}
+}
+
+type RabbitmqFeatureFlagsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqFeatureFlagModel struct {
+	Name        types.String `tfsdk:"name"`
+	State       types.String `tfsdk:"state"`
+	Stability   types.String `tfsdk:"stability"`
+	Description types.String `tfsdk:"description"`
+	ProvidedBy  types.String `tfsdk:"provided_by"`
+}
+
+type RabbitmqFeatureFlagsDataSourceModel struct {
+	FeatureFlags          []RabbitmqFeatureFlagModel `tfsdk:"feature_flags"`
+	DisabledStableFlags   types.List                 `tfsdk:"disabled_stable_flags"`
+	AllStableFlagsEnabled types.Bool                 `tfsdk:"all_stable_flags_enabled"`
+}
+
+func (d *RabbitmqFeatureFlagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqFeatureFlagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flags"
+}
+
+func (d *RabbitmqFeatureFlagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"feature_flags": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The feature flags of the cluster, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the feature flag.",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state of the feature flag: enabled, disabled or unsupported.",
+						},
+						"stability": schema.StringAttribute{
+							Computed:    true,
+							Description: "The stability of the feature flag, e.g. stable, required or experimental.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the feature flag.",
+						},
+						"provided_by": schema.StringAttribute{
+							Computed:    true,
+							Description: "The RabbitMQ component or plugin that provides the feature flag.",
+						},
+					},
+				},
+			},
+			"disabled_stable_flags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the stable feature flags that are not enabled, sorted by name.",
+			},
+			"all_stable_flags_enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether every stable feature flag is enabled.",
+			},
+		},
+	}
+}
+
+func (d *RabbitmqFeatureFlagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqFeatureFlagsDataSourceModel
+
+	tflog.Trace(ctx, "listing rabbitmq feature flags")
+
+	flags, err := d.providerData.client(ctx).ListFeatureFlags()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Feature Flags",
+			fmt.Sprintf("Could not list RabbitMQ feature flags: %s", err.Error()),
+		)
+		return
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	data.FeatureFlags = make([]RabbitmqFeatureFlagModel, 0, len(flags))
+	disabled := []attr.Value{}
+	for _, f := range flags {
+		data.FeatureFlags = append(data.FeatureFlags, RabbitmqFeatureFlagModel{
+			Name:        types.StringValue(f.Name),
+			State:       types.StringValue(string(f.State)),
+			Stability:   types.StringValue(string(f.Stability)),
+			Description: types.StringValue(f.Desc),
+			ProvidedBy:  types.StringValue(f.ProvidedBy),
+		})
+
+		if f.Stability == rabbithole.StabilityStable && f.State != rabbithole.StateEnabled {
+			disabled = append(disabled, types.StringValue(f.Name))
+		}
+	}
+
+	data.DisabledStableFlags = types.ListValueMust(types.StringType, disabled)
+	data.AllStableFlagsEnabled = types.BoolValue(len(disabled) == 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqHealthChecksDataSource{}
+
+var certificateExpirationUnits = []string{"days", "weeks", "months", "years"}
+
+func NewRabbitmqHealthChecksDataSource() datasource.DataSource {
+	return &RabbitmqHealthChecksDataSource{}
+}
+
+type RabbitmqHealthChecksDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqCertificateExpirationModel struct {
+	Within types.Int64  `tfsdk:"within"`
+	Unit   types.String `tfsdk:"unit"`
+}
+
+type RabbitmqHealthCheckResultModel struct {
+	Name   types.String `tfsdk:"name"`
+	Passed types.Bool   `tfsdk:"passed"`
+	Reason types.String `tfsdk:"reason"`
+}
+
+type RabbitmqHealthChecksDataSourceModel struct {
+	Alarms                   types.Bool                          `tfsdk:"alarms"`
+	LocalAlarms              types.Bool                          `tfsdk:"local_alarms"`
+	CertificateExpiration    *RabbitmqCertificateExpirationModel `tfsdk:"certificate_expiration"`
+	PortListeners            types.List                          `tfsdk:"port_listeners"`
+	ProtocolListeners        types.List                          `tfsdk:"protocol_listeners"`
+	VirtualHosts             types.Bool                          `tfsdk:"virtual_hosts"`
+	NodeIsQuorumCritical     types.Bool                          `tfsdk:"node_is_quorum_critical"`
+	NodeIsMirrorSyncCritical types.Bool                          `tfsdk:"node_is_mirror_sync_critical"`
+	Passed                   types.Bool                          `tfsdk:"passed"`
+	Results                  []RabbitmqHealthCheckResultModel    `tfsdk:"results"`
+}
+
+func (d *RabbitmqHealthChecksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqHealthChecksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health_checks"
+}
+
+func (d *RabbitmqHealthChecksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"alarms": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check that no resource alarms are in effect in the cluster.",
+			},
+			"local_alarms": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check that no resource alarms are in effect on the node serving the request.",
+			},
+			"certificate_expiration": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Check that no TLS listener certificate expires within the given window.",
+				Attributes: map[string]schema.Attribute{
+					"within": schema.Int64Attribute{
+						Required:    true,
+						Description: "The length of the window, in units.",
+					},
+					"unit": schema.StringAttribute{
+						Required:    true,
+						Description: fmt.Sprintf("The unit of the window. Valid values: %s.", strings.Join(certificateExpirationUnits, ", ")),
+					},
+				},
+			},
+			"port_listeners": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "Check that there is an active listener on each of these ports.",
+			},
+			"protocol_listeners": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Check that there is an active listener for each of these protocols, e.g. amqp091 or https.",
+			},
+			"virtual_hosts": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check that all vhosts are running on the node serving the request.",
+			},
+			"node_is_quorum_critical": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check that shutting down the node serving the request would not leave any quorum queue without a quorum.",
+			},
+			"node_is_mirror_sync_critical": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check that shutting down the node serving the request would not leave any classic mirrored queue without a synchronised mirror.",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether every requested check passed.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The result of each requested check.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the check, as it appears in the /api/health/checks path.",
+						},
+						"passed": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the check passed.",
+						},
+						"reason": schema.StringAttribute{
+							Computed:    true,
+							Description: "Why the check failed. Null when the check passed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqHealthChecksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqHealthChecksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ports []int64
+	if !data.PortListeners.IsNull() {
+		resp.Diagnostics.Append(data.PortListeners.ElementsAs(ctx, &ports, false)...)
+	}
+	var protocols []string
+	if !data.ProtocolListeners.IsNull() {
+		resp.Diagnostics.Append(data.ProtocolListeners.ElementsAs(ctx, &protocols, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CertificateExpiration != nil {
+		within := data.CertificateExpiration.Within.ValueInt64()
+		unit := data.CertificateExpiration.Unit.ValueString()
+		if within < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("certificate_expiration").AtName("within"),
+				"Invalid Certificate Expiration Window",
+				fmt.Sprintf("within must not be negative, got %d.", within),
+			)
+		}
+		if !slices.Contains(certificateExpirationUnits, unit) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("certificate_expiration").AtName("unit"),
+				"Invalid Certificate Expiration Unit",
+				fmt.Sprintf("unit must be one of %s, got %q.", strings.Join(certificateExpirationUnits, ", "), unit),
+			)
+		}
+	}
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("port_listeners"),
+				"Invalid Port",
+				fmt.Sprintf("Ports must be between 1 and 65535, got %d.", port),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rmqc := d.providerData.client(ctx)
+
+	type check struct {
+		name string
+		run  func() (bool, string, error)
+	}
+	var checks []check
+
+	if data.Alarms.ValueBool() {
+		checks = append(checks, check{"alarms", func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckAlarms()
+			return rec.Ok(), alarmsReason(rec), err
+		}})
+	}
+	if data.LocalAlarms.ValueBool() {
+		checks = append(checks, check{"local-alarms", func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckLocalAlarms()
+			return rec.Ok(), alarmsReason(rec), err
+		}})
+	}
+	if data.CertificateExpiration != nil {
+		within := uint(data.CertificateExpiration.Within.ValueInt64())
+		unit := rabbithole.TimeUnit(data.CertificateExpiration.Unit.ValueString())
+		checks = append(checks, check{fmt.Sprintf("certificate-expiration/%d/%s", within, unit), func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckCertificateExpiration(within, unit)
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+	for _, port := range ports {
+		checks = append(checks, check{fmt.Sprintf("port-listener/%d", port), func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckPortListener(uint(port))
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+	for _, protocol := range protocols {
+		checks = append(checks, check{"protocol-listener/" + protocol, func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckProtocolListener(rabbithole.Protocol(protocol))
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+	if data.VirtualHosts.ValueBool() {
+		checks = append(checks, check{"virtual-hosts", func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckVirtualHosts()
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+	if data.NodeIsQuorumCritical.ValueBool() {
+		checks = append(checks, check{"node-is-quorum-critical", func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckNodeIsQuorumCritical()
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+	if data.NodeIsMirrorSyncCritical.ValueBool() {
+		checks = append(checks, check{"node-is-mirror-sync-critical", func() (bool, string, error) {
+			rec, err := rmqc.HealthCheckNodeIsMirrorSyncCritical()
+			return rec.Ok(), rec.Reason, err
+		}})
+	}
+
+	if len(checks) == 0 {
+		resp.Diagnostics.AddError(
+			"No Health Checks Requested",
+			"Enable at least one health check, e.g. alarms = true.",
+		)
+		return
+	}
+
+	data.Passed = types.BoolValue(true)
+	data.Results = make([]RabbitmqHealthCheckResultModel, 0, len(checks))
+	for _, c := range checks {
+		tflog.Trace(ctx, "running rabbitmq health check", map[string]interface{}{
+			"check": c.name,
+		})
+
+		passed, reason, err := c.run()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Running RabbitMQ Health Check",
+				fmt.Sprintf("Could not run RabbitMQ health check %s: %s", c.name, err.Error()),
+			)
+			return
+		}
+
+		result := RabbitmqHealthCheckResultModel{
+			Name:   types.StringValue(c.name),
+			Passed: types.BoolValue(passed),
+			Reason: types.StringNull(),
+		}
+		if !passed {
+			result.Reason = types.StringValue(reason)
+			data.Passed = types.BoolValue(false)
+		}
+		data.Results = append(data.Results, result)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// alarmsReason appends the alarms in effect to the reason reported by the
+// alarms checks, which on its own does not say which node or resource is affected.
+func alarmsReason(rec rabbithole.ResourceAlarmCheckStatus) string {
+	if len(rec.Alarms) == 0 {
+		return rec.Reason
+	}
+
+	alarms := make([]string, 0, len(rec.Alarms))
+	for _, a := range rec.Alarms {
+		alarms = append(alarms, fmt.Sprintf("%s on %s", a.Resource, a.Node))
+	}
+
+	return fmt.Sprintf("%s: %s", rec.Reason, strings.Join(alarms, ", "))
+}
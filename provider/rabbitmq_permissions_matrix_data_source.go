@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqPermissionsMatrixDataSource{}
+
+// defaultBroadPatterns are the patterns that grant access to every resource
+// name and are reported as over-broad when broad_patterns is unset.
+var defaultBroadPatterns = []string{".*", "^.*", ".*$", "^.*$", ".+", "^.+$"}
+
+var permissionKinds = []string{"configure", "write", "read"}
+
+func NewRabbitmqPermissionsMatrixDataSource() datasource.DataSource {
+	return &RabbitmqPermissionsMatrixDataSource{}
+}
+
+type RabbitmqPermissionsMatrixDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqPermissionsMatrixEntryModel struct {
+	Type      types.String `tfsdk:"type"`
+	User      types.String `tfsdk:"user"`
+	Vhost     types.String `tfsdk:"vhost"`
+	Exchange  types.String `tfsdk:"exchange"`
+	Configure types.String `tfsdk:"configure"`
+	Write     types.String `tfsdk:"write"`
+	Read      types.String `tfsdk:"read"`
+	OverBroad types.List   `tfsdk:"over_broad"`
+}
+
+type RabbitmqPermissionsMatrixDataSourceModel struct {
+	Users         types.List                            `tfsdk:"users"`
+	Vhosts        types.List                            `tfsdk:"vhosts"`
+	OverBroadOn   types.List                            `tfsdk:"over_broad_on"`
+	BroadPatterns types.List                            `tfsdk:"broad_patterns"`
+	EntryCount    types.Int64                           `tfsdk:"entry_count"`
+	Entries       []RabbitmqPermissionsMatrixEntryModel `tfsdk:"entries"`
+}
+
+func (d *RabbitmqPermissionsMatrixDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqPermissionsMatrixDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permissions_matrix"
+}
+
+func (d *RabbitmqPermissionsMatrixDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"users": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list entries of these users.",
+			},
+			"vhosts": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list entries in these vhosts.",
+			},
+			"over_broad_on": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list entries where at least one of these permissions, configure, write or read, matches one of broad_patterns.",
+			},
+			"broad_patterns": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The patterns reported as over-broad. Defaults to .*, ^.*, .*$, ^.*$, .+ and ^.+$.",
+			},
+			"entry_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of matching entries.",
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching permissions and topic permissions, sorted by user, vhost, type and exchange.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The kind of entry, permissions or topic_permissions.",
+						},
+						"user": schema.StringAttribute{
+							Computed:    true,
+							Description: "The user the entry applies to.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost the entry applies to.",
+						},
+						"exchange": schema.StringAttribute{
+							Computed:    true,
+							Description: "The exchange the topic permissions apply to. Null for permissions.",
+						},
+						"configure": schema.StringAttribute{
+							Computed:    true,
+							Description: "The configure permissions. Null for topic permissions.",
+						},
+						"write": schema.StringAttribute{
+							Computed:    true,
+							Description: "The write permissions.",
+						},
+						"read": schema.StringAttribute{
+							Computed:    true,
+							Description: "The read permissions.",
+						},
+						"over_broad": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The permissions of the entry, among configure, write and read, that match one of broad_patterns.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqPermissionsMatrixDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqPermissionsMatrixDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var users, vhosts, overBroadOn []string
+	resp.Diagnostics.Append(data.Users.ElementsAs(ctx, &users, false)...)
+	resp.Diagnostics.Append(data.Vhosts.ElementsAs(ctx, &vhosts, false)...)
+	resp.Diagnostics.Append(data.OverBroadOn.ElementsAs(ctx, &overBroadOn, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, kind := range overBroadOn {
+		if !slices.Contains(permissionKinds, kind) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("over_broad_on"),
+				"Invalid Permission",
+				fmt.Sprintf("Unknown permission %q. Valid values: %s.", kind, strings.Join(permissionKinds, ", ")),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	broadPatterns := defaultBroadPatterns
+	if data.BroadPatterns.IsNull() || data.BroadPatterns.IsUnknown() {
+		patterns := make([]attr.Value, 0, len(broadPatterns))
+		for _, p := range broadPatterns {
+			patterns = append(patterns, types.StringValue(p))
+		}
+		data.BroadPatterns = types.ListValueMust(types.StringType, patterns)
+	} else {
+		broadPatterns = nil
+		resp.Diagnostics.Append(data.BroadPatterns.ElementsAs(ctx, &broadPatterns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq permissions matrix", map[string]interface{}{
+		"users":  users,
+		"vhosts": vhosts,
+	})
+
+	rmqc := d.providerData.client(ctx)
+	permissions, err := rmqc.ListPermissions()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Permissions",
+			fmt.Sprintf("Could not list RabbitMQ permissions: %s", err.Error()),
+		)
+		return
+	}
+
+	topicPermissions, err := rmqc.ListTopicPermissions()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Topic Permissions",
+			fmt.Sprintf("Could not list RabbitMQ topic permissions: %s", err.Error()),
+		)
+		return
+	}
+
+	matches := func(user, vhost string) bool {
+		if len(users) > 0 && !slices.Contains(users, user) {
+			return false
+		}
+		if len(vhosts) > 0 && !slices.Contains(vhosts, vhost) {
+			return false
+		}
+		return true
+	}
+
+	// add records an entry along with the permissions that match a broad
+	// pattern. Topic permissions have no configure permission.
+	add := func(entry RabbitmqPermissionsMatrixEntryModel) {
+		values := map[string]types.String{
+			"configure": entry.Configure,
+			"write":     entry.Write,
+			"read":      entry.Read,
+		}
+
+		overBroad := []attr.Value{}
+		selected := len(overBroadOn) == 0
+		for _, kind := range permissionKinds {
+			v := values[kind]
+			if v.IsNull() || !slices.Contains(broadPatterns, v.ValueString()) {
+				continue
+			}
+			overBroad = append(overBroad, types.StringValue(kind))
+			if slices.Contains(overBroadOn, kind) {
+				selected = true
+			}
+		}
+		if !selected {
+			return
+		}
+
+		entry.OverBroad = types.ListValueMust(types.StringType, overBroad)
+		data.Entries = append(data.Entries, entry)
+	}
+
+	data.Entries = []RabbitmqPermissionsMatrixEntryModel{}
+	for _, p := range permissions {
+		if !matches(p.User, p.Vhost) {
+			continue
+		}
+		add(RabbitmqPermissionsMatrixEntryModel{
+			Type:      types.StringValue("permissions"),
+			User:      types.StringValue(p.User),
+			Vhost:     types.StringValue(p.Vhost),
+			Exchange:  types.StringNull(),
+			Configure: types.StringValue(p.Configure),
+			Write:     types.StringValue(p.Write),
+			Read:      types.StringValue(p.Read),
+		})
+	}
+	for _, p := range topicPermissions {
+		if !matches(p.User, p.Vhost) {
+			continue
+		}
+		add(RabbitmqPermissionsMatrixEntryModel{
+			Type:      types.StringValue("topic_permissions"),
+			User:      types.StringValue(p.User),
+			Vhost:     types.StringValue(p.Vhost),
+			Exchange:  types.StringValue(p.Exchange),
+			Configure: types.StringNull(),
+			Write:     types.StringValue(p.Write),
+			Read:      types.StringValue(p.Read),
+		})
+	}
+
+	sort.SliceStable(data.Entries, func(i, j int) bool {
+		a, b := data.Entries[i], data.Entries[j]
+		if a.User.ValueString() != b.User.ValueString() {
+			return a.User.ValueString() < b.User.ValueString()
+		}
+		if a.Vhost.ValueString() != b.Vhost.ValueString() {
+			return a.Vhost.ValueString() < b.Vhost.ValueString()
+		}
+		if a.Type.ValueString() != b.Type.ValueString() {
+			return a.Type.ValueString() < b.Type.ValueString()
+		}
+		return a.Exchange.ValueString() < b.Exchange.ValueString()
+	})
+
+	data.EntryCount = types.Int64Value(int64(len(data.Entries)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -17,6 +17,7 @@ import (
 )
 
 var _ resource.Resource = &RabbitmqPermissionsResource{}
+var _ resource.ResourceWithModifyPlan = &RabbitmqPermissionsResource{}
 
 func NewRabbitmqPermissionsResource() resource.Resource {
 	return &RabbitmqPermissionsResource{}
@@ -59,10 +60,7 @@ func (r *RabbitmqPermissionsResource) Schema(ctx context.Context, req resource.S
 			"vhost": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "The vhost to grant permissions for.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "The vhost to grant permissions for. Defaults to the default_vhost of the provider.",
 			},
 			"configure": schema.StringAttribute{
 				Required:    true,
@@ -101,6 +99,10 @@ func (r *RabbitmqPermissionsResource) ImportState(ctx context.Context, req resou
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
+func (r *RabbitmqPermissionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planVhost(ctx, req, resp)
+}
+
 func (r *RabbitmqPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan RabbitmqPermissionsResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -109,8 +111,8 @@ func (r *RabbitmqPermissionsResource) Create(ctx context.Context, req resource.C
 	}
 
 	user := plan.User.ValueString()
-	vhost := "/"
-	if !plan.Vhost.IsNull() {
+	vhost := r.providerData.defaultVhost
+	if !plan.Vhost.IsNull() && !plan.Vhost.IsUnknown() {
 		vhost = plan.Vhost.ValueString()
 	}
 
@@ -123,7 +125,7 @@ func (r *RabbitmqPermissionsResource) Create(ctx context.Context, req resource.C
 		"vhost": vhost,
 	})
 
-	err := r.setPermissions(user, vhost, &plan)
+	err := r.setPermissions(ctx, user, vhost, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating RabbitMQ Permissions",
@@ -150,7 +152,7 @@ func (r *RabbitmqPermissionsResource) Read(ctx context.Context, req resource.Rea
 		"vhost": vhost,
 	})
 
-	permissions, err := r.providerData.rabbitmqClient.GetPermissionsIn(vhost, user)
+	permissions, err := r.providerData.client(ctx).GetPermissionsIn(vhost, user)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading RabbitMQ Permissions",
@@ -181,7 +183,7 @@ func (r *RabbitmqPermissionsResource) Update(ctx context.Context, req resource.U
 		"vhost": vhost,
 	})
 
-	err := r.setPermissions(user, vhost, &plan)
+	err := r.setPermissions(ctx, user, vhost, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating RabbitMQ Permissions",
@@ -208,7 +210,7 @@ func (r *RabbitmqPermissionsResource) Delete(ctx context.Context, req resource.D
 		"vhost": vhost,
 	})
 
-	response, err := r.providerData.rabbitmqClient.ClearPermissionsIn(vhost, user)
+	response, err := r.providerData.client(ctx).ClearPermissionsIn(vhost, user)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting RabbitMQ Permissions",
@@ -230,14 +232,14 @@ func (r *RabbitmqPermissionsResource) Delete(ctx context.Context, req resource.D
 	}
 }
 
-func (r *RabbitmqPermissionsResource) setPermissions(user, vhost string, plan *RabbitmqPermissionsResourceModel) error {
+func (r *RabbitmqPermissionsResource) setPermissions(ctx context.Context, user, vhost string, plan *RabbitmqPermissionsResourceModel) error {
 	permissions := rabbithole.Permissions{
 		Configure: plan.Configure.ValueString(),
 		Write:     plan.Write.ValueString(),
 		Read:      plan.Read.ValueString(),
 	}
 
-	response, err := r.providerData.rabbitmqClient.UpdatePermissionsIn(vhost, user, permissions)
+	response, err := r.providerData.client(ctx).UpdatePermissionsIn(vhost, user, permissions)
 	if err != nil {
 		return err
 	}
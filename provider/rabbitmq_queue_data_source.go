@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqQueueDataSource{}
+
+func NewRabbitmqQueueDataSource() datasource.DataSource {
+	return &RabbitmqQueueDataSource{}
+}
+
+type RabbitmqQueueDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqQueueDataSourceModel struct {
+	Name                   types.String `tfsdk:"name"`
+	Vhost                  types.String `tfsdk:"vhost"`
+	Type                   types.String `tfsdk:"type"`
+	Durable                types.Bool   `tfsdk:"durable"`
+	State                  types.String `tfsdk:"state"`
+	Node                   types.String `tfsdk:"node"`
+	Leader                 types.String `tfsdk:"leader"`
+	Members                types.List   `tfsdk:"members"`
+	Policy                 types.String `tfsdk:"policy"`
+	Messages               types.Int64  `tfsdk:"messages"`
+	MessagesReady          types.Int64  `tfsdk:"messages_ready"`
+	MessagesUnacknowledged types.Int64  `tfsdk:"messages_unacknowledged"`
+	ConsumerCount          types.Int64  `tfsdk:"consumer_count"`
+	Memory                 types.Int64  `tfsdk:"memory"`
+}
+
+func (d *RabbitmqQueueDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqQueueDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queue"
+}
+
+func (d *RabbitmqQueueDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the queue.",
+			},
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The vhost of the queue. Defaults to /.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The queue type, e.g. classic, quorum or stream.",
+			},
+			"durable": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the queue is durable.",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "The state of the queue, e.g. running.",
+			},
+			"node": schema.StringAttribute{
+				Computed:    true,
+				Description: "The node hosting the queue, or its leader replica.",
+			},
+			"leader": schema.StringAttribute{
+				Computed:    true,
+				Description: "The node hosting the leader replica. Null for queues that are not replicated.",
+			},
+			"members": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The nodes hosting a replica. Empty for queues that are not replicated.",
+			},
+			"policy": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the user policy applied to the queue.",
+			},
+			"messages": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Total number of messages in the queue.",
+			},
+			"messages_ready": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of messages ready for delivery.",
+			},
+			"messages_unacknowledged": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of messages delivered but not yet acknowledged.",
+			},
+			"consumer_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of consumers.",
+			},
+			"memory": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Memory used by the queue, in bytes.",
+			},
+		},
+	}
+}
+
+func (d *RabbitmqQueueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqQueueDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	vhost := "/"
+	if !data.Vhost.IsNull() {
+		vhost = data.Vhost.ValueString()
+	}
+
+	tflog.Trace(ctx, "reading rabbitmq queue", map[string]interface{}{
+		"name":  name,
+		"vhost": vhost,
+	})
+
+	queue, err := d.providerData.client(ctx).GetQueue(vhost, name)
+	if err != nil {
+		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"RabbitMQ Queue Not Found",
+				fmt.Sprintf("RabbitMQ queue %s does not exist in vhost %s.", name, vhost),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading RabbitMQ Queue",
+			fmt.Sprintf("Could not read RabbitMQ queue %s in vhost %s: %s", name, vhost, err.Error()),
+		)
+		return
+	}
+
+	if queue == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"RabbitMQ Queue Not Found",
+			fmt.Sprintf("RabbitMQ queue %s does not exist in vhost %s.", name, vhost),
+		)
+		return
+	}
+
+	data.Vhost = types.StringValue(vhost)
+	data.Type = types.StringValue(queue.Type)
+	data.Durable = types.BoolValue(queue.Durable)
+	data.State = types.StringValue(queue.Status)
+	data.Node = types.StringValue(queue.Node)
+	data.Policy = types.StringValue(queue.Policy)
+	data.Messages = types.Int64Value(int64(queue.Messages))
+	data.MessagesReady = types.Int64Value(int64(queue.MessagesReady))
+	data.MessagesUnacknowledged = types.Int64Value(int64(queue.MessagesUnacknowledged))
+	data.ConsumerCount = types.Int64Value(int64(queue.Consumers))
+	data.Memory = types.Int64Value(queue.Memory)
+
+	if queue.Leader == "" {
+		data.Leader = types.StringNull()
+	} else {
+		data.Leader = types.StringValue(queue.Leader)
+	}
+
+	members := make([]attr.Value, 0, len(queue.Members))
+	for _, m := range queue.Members {
+		members = append(members, types.StringValue(m))
+	}
+	data.Members = types.ListValueMust(types.StringType, members)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,423 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqQueuesDataSource{}
+
+// defaultPageSize and maxPageSize bound the page_size of the data sources
+// that list objects page by page. The management API rejects page sizes above
+// 500.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500
+)
+
+// queueColumns lists the queue attributes that can be requested through the
+// columns argument. Each name matches the management API field of the same name.
+var queueColumns = []string{
+	"type",
+	"durable",
+	"auto_delete",
+	"exclusive",
+	"state",
+	"node",
+	"policy",
+	"operator_policy",
+	"effective_policy_definition",
+	"messages",
+	"messages_ready",
+	"messages_unacknowledged",
+	"consumers",
+}
+
+// pagedQueues is a page of the queues listing. rabbit-hole's PagedQueueInfo
+// does not decode the operator policy or the effective policy definition.
+type pagedQueues struct {
+	PageCount     int           `json:"page_count"`
+	FilteredCount int           `json:"filtered_count"`
+	TotalCount    int           `json:"total_count"`
+	Items         []queueDetail `json:"items"`
+}
+
+type queueDetail struct {
+	rabbithole.QueueInfo
+	OperatorPolicy string `json:"operator_policy"`
+	// EffectivePolicyDefinition is an empty JSON array rather than an object
+	// when no policy applies to the queue.
+	EffectivePolicyDefinition json.RawMessage `json:"effective_policy_definition"`
+}
+
+func NewRabbitmqQueuesDataSource() datasource.DataSource {
+	return &RabbitmqQueuesDataSource{}
+}
+
+type RabbitmqQueuesDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqQueuesQueueModel struct {
+	Name                   types.String `tfsdk:"name"`
+	Vhost                  types.String `tfsdk:"vhost"`
+	Type                   types.String `tfsdk:"type"`
+	Durable                types.Bool   `tfsdk:"durable"`
+	AutoDelete             types.Bool   `tfsdk:"auto_delete"`
+	Exclusive              types.Bool   `tfsdk:"exclusive"`
+	State                  types.String `tfsdk:"state"`
+	Node                   types.String `tfsdk:"node"`
+	Policy                 types.String `tfsdk:"policy"`
+	OperatorPolicy         types.String `tfsdk:"operator_policy"`
+	EffectivePolicy        types.String `tfsdk:"effective_policy_definition"`
+	Messages               types.Int64  `tfsdk:"messages"`
+	MessagesReady          types.Int64  `tfsdk:"messages_ready"`
+	MessagesUnacknowledged types.Int64  `tfsdk:"messages_unacknowledged"`
+	Consumers              types.Int64  `tfsdk:"consumers"`
+}
+
+type RabbitmqQueuesDataSourceModel struct {
+	Vhost         types.String               `tfsdk:"vhost"`
+	Name          types.String               `tfsdk:"name"`
+	UseRegex      types.Bool                 `tfsdk:"use_regex"`
+	Page          types.Int64                `tfsdk:"page"`
+	PageSize      types.Int64                `tfsdk:"page_size"`
+	Columns       types.List                 `tfsdk:"columns"`
+	TotalCount    types.Int64                `tfsdk:"total_count"`
+	FilteredCount types.Int64                `tfsdk:"filtered_count"`
+	PageCount     types.Int64                `tfsdk:"page_count"`
+	Queues        []RabbitmqQueuesQueueModel `tfsdk:"queues"`
+}
+
+func (d *RabbitmqQueuesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqQueuesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queues"
+}
+
+func (d *RabbitmqQueuesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list queues in this vhost. All vhosts visible to the provider user are listed when unset.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list queues whose name contains this value, or matches it when use_regex is true.",
+			},
+			"use_regex": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Treat name as a regular expression.",
+			},
+			"page": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Fetch only this page. All pages are fetched when unset.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of queues per request. Defaults to %d, maximum %d.", defaultPageSize, maxPageSize),
+			},
+			"columns": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: fmt.Sprintf("Only fetch these queue attributes. name and vhost are always fetched; attributes that are not requested are null. Valid values: %s.", strings.Join(queueColumns, ", ")),
+			},
+			"total_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of queues visible to the provider user.",
+			},
+			"filtered_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of queues matching the filters.",
+			},
+			"page_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of pages matching the filters at the given page size.",
+			},
+			"queues": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching queues.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the queue.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the queue.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The queue type, e.g. classic, quorum or stream.",
+						},
+						"durable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the queue is durable.",
+						},
+						"auto_delete": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the queue is deleted when its last consumer unsubscribes.",
+						},
+						"exclusive": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the queue is exclusive to one connection.",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state of the queue, e.g. running.",
+						},
+						"node": schema.StringAttribute{
+							Computed:    true,
+							Description: "The node hosting the queue leader.",
+						},
+						"policy": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the user policy applied to the queue.",
+						},
+						"operator_policy": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the operator policy applied to the queue.",
+						},
+						"effective_policy_definition": schema.StringAttribute{
+							Computed:    true,
+							Description: "The policy definition in effect for the queue as a JSON object, merging the user and operator policies.",
+						},
+						"messages": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Total number of messages in the queue.",
+						},
+						"messages_ready": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages ready for delivery.",
+						},
+						"messages_unacknowledged": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages delivered but not yet acknowledged.",
+						},
+						"consumers": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of consumers.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqQueuesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqQueuesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+
+	pageSize := int64(defaultPageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_size"),
+			"Invalid Page Size",
+			fmt.Sprintf("page_size must be between 1 and %d, got %d.", maxPageSize, pageSize),
+		)
+		return
+	}
+
+	page := int64(1)
+	if !data.Page.IsNull() {
+		page = data.Page.ValueInt64()
+		if page < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("page"),
+				"Invalid Page",
+				fmt.Sprintf("page must be at least 1, got %d.", page),
+			)
+			return
+		}
+	}
+
+	var columns []string
+	if !data.Columns.IsNull() {
+		resp.Diagnostics.Append(data.Columns.ElementsAs(ctx, &columns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, c := range columns {
+			if !slices.Contains(queueColumns, c) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("columns"),
+					"Invalid Column",
+					fmt.Sprintf("Unknown queue column %q. Valid values: %s.", c, strings.Join(queueColumns, ", ")),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	params := url.Values{}
+	params.Set("page_size", strconv.FormatInt(pageSize, 10))
+	if !data.Name.IsNull() && data.Name.ValueString() != "" {
+		params.Set("name", data.Name.ValueString())
+		if data.UseRegex.ValueBool() {
+			params.Set("use_regex", "true")
+		}
+	}
+	if columns != nil {
+		params.Set("columns", strings.Join(append([]string{"name", "vhost"}, columns...), ","))
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq queues", map[string]interface{}{
+		"vhost":     vhost,
+		"page_size": pageSize,
+	})
+
+	queues := []RabbitmqQueuesQueueModel{}
+	for {
+		params.Set("page", strconv.FormatInt(page, 10))
+
+		apiPath := "queues"
+		if vhost != "" {
+			apiPath += "/" + url.PathEscape(vhost)
+		}
+
+		var paged pagedQueues
+		if err := d.providerData.getJSON(ctx, apiPath+"?"+params.Encode(), &paged); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing RabbitMQ Queues",
+				fmt.Sprintf("Could not list RabbitMQ queues (page %d): %s", page, err.Error()),
+			)
+			return
+		}
+
+		for _, q := range paged.Items {
+			m, err := newQueuesQueueModel(q, columns)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading RabbitMQ Queue",
+					fmt.Sprintf("Could not decode the effective policy definition of queue %q in vhost %q: %s", q.Name, q.Vhost, err.Error()),
+				)
+				return
+			}
+			queues = append(queues, m)
+		}
+
+		data.TotalCount = types.Int64Value(int64(paged.TotalCount))
+		data.FilteredCount = types.Int64Value(int64(paged.FilteredCount))
+		data.PageCount = types.Int64Value(int64(paged.PageCount))
+
+		if !data.Page.IsNull() || page >= int64(paged.PageCount) {
+			break
+		}
+		page++
+	}
+
+	data.Queues = queues
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// newQueuesQueueModel converts a queue to its model. When columns is non-nil,
+// attributes missing from it are left null because the API did not return them.
+func newQueuesQueueModel(q queueDetail, columns []string) (RabbitmqQueuesQueueModel, error) {
+	fetched := func(column string) bool {
+		return columns == nil || slices.Contains(columns, column)
+	}
+
+	m := RabbitmqQueuesQueueModel{
+		Name:                   types.StringValue(q.Name),
+		Vhost:                  types.StringValue(q.Vhost),
+		Type:                   types.StringNull(),
+		Durable:                types.BoolNull(),
+		AutoDelete:             types.BoolNull(),
+		Exclusive:              types.BoolNull(),
+		State:                  types.StringNull(),
+		Node:                   types.StringNull(),
+		Policy:                 types.StringNull(),
+		OperatorPolicy:         types.StringNull(),
+		EffectivePolicy:        types.StringNull(),
+		Messages:               types.Int64Null(),
+		MessagesReady:          types.Int64Null(),
+		MessagesUnacknowledged: types.Int64Null(),
+		Consumers:              types.Int64Null(),
+	}
+
+	if fetched("type") {
+		m.Type = types.StringValue(q.Type)
+	}
+	if fetched("durable") {
+		m.Durable = types.BoolValue(q.Durable)
+	}
+	if fetched("auto_delete") {
+		m.AutoDelete = types.BoolValue(bool(q.AutoDelete))
+	}
+	if fetched("exclusive") {
+		m.Exclusive = types.BoolValue(q.Exclusive)
+	}
+	if fetched("state") {
+		m.State = types.StringValue(q.Status)
+	}
+	if fetched("node") {
+		m.Node = types.StringValue(q.Node)
+	}
+	if fetched("policy") {
+		m.Policy = types.StringValue(q.Policy)
+	}
+	if fetched("operator_policy") {
+		m.OperatorPolicy = types.StringValue(q.OperatorPolicy)
+	}
+	if fetched("effective_policy_definition") {
+		definition := map[string]interface{}{}
+		raw := bytes.TrimSpace(q.EffectivePolicyDefinition)
+		if len(raw) > 0 && !bytes.Equal(raw, []byte("[]")) && !bytes.Equal(raw, []byte("null")) {
+			if err := json.Unmarshal(raw, &definition); err != nil {
+				return m, err
+			}
+		}
+		encoded, err := json.Marshal(definition)
+		if err != nil {
+			return m, err
+		}
+		m.EffectivePolicy = types.StringValue(string(encoded))
+	}
+	if fetched("messages") {
+		m.Messages = types.Int64Value(int64(q.Messages))
+	}
+	if fetched("messages_ready") {
+		m.MessagesReady = types.Int64Value(int64(q.MessagesReady))
+	}
+	if fetched("messages_unacknowledged") {
+		m.MessagesUnacknowledged = types.Int64Value(int64(q.MessagesUnacknowledged))
+	}
+	if fetched("consumers") {
+		m.Consumers = types.Int64Value(int64(q.Consumers))
+	}
+
+	return m, nil
+}
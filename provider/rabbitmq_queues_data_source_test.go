@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewQueuesQueueModelPolicies(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		columns    []string
+		policy     types.String
+		operator   types.String
+		definition types.String
+	}{
+		{
+			name:       "user and operator policies",
+			body:       `{"name":"q","vhost":"/","policy":"ha","operator_policy":"limits","effective_policy_definition":{"max-length":1000,"ha-mode":"all"}}`,
+			policy:     types.StringValue("ha"),
+			operator:   types.StringValue("limits"),
+			definition: types.StringValue(`{"ha-mode":"all","max-length":1000}`),
+		},
+		{
+			name:       "no policy",
+			body:       `{"name":"q","vhost":"/","effective_policy_definition":[]}`,
+			policy:     types.StringValue(""),
+			operator:   types.StringValue(""),
+			definition: types.StringValue(`{}`),
+		},
+		{
+			name:       "columns without policies",
+			body:       `{"name":"q","vhost":"/","messages":3}`,
+			columns:    []string{"messages"},
+			policy:     types.StringNull(),
+			operator:   types.StringNull(),
+			definition: types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q queueDetail
+			if err := json.Unmarshal([]byte(tt.body), &q); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := newQueuesQueueModel(q, tt.columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m.Policy.Equal(tt.policy) {
+				t.Errorf("policy = %s, want %s", m.Policy, tt.policy)
+			}
+			if !m.OperatorPolicy.Equal(tt.operator) {
+				t.Errorf("operator_policy = %s, want %s", m.OperatorPolicy, tt.operator)
+			}
+			if !m.EffectivePolicy.Equal(tt.definition) {
+				t.Errorf("effective_policy_definition = %s, want %s", m.EffectivePolicy, tt.definition)
+			}
+		})
+	}
+}
+
+func TestNewQueuesQueueModelInvalidDefinition(t *testing.T) {
+	q := queueDetail{EffectivePolicyDefinition: json.RawMessage(`"ha"`)}
+	if _, err := newQueuesQueueModel(q, nil); err == nil {
+		t.Fatal("expected an error for a definition that is not an object")
+	}
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqShovelStatusDataSource{}
+
+func NewRabbitmqShovelStatusDataSource() datasource.DataSource {
+	return &RabbitmqShovelStatusDataSource{}
+}
+
+type RabbitmqShovelStatusDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqShovelStatusModel struct {
+	Name                   types.String `tfsdk:"name"`
+	Vhost                  types.String `tfsdk:"vhost"`
+	Type                   types.String `tfsdk:"type"`
+	State                  types.String `tfsdk:"state"`
+	Node                   types.String `tfsdk:"node"`
+	Timestamp              types.String `tfsdk:"timestamp"`
+	SourceUri              types.String `tfsdk:"source_uri"`
+	SourceProtocol         types.String `tfsdk:"source_protocol"`
+	SourceQueue            types.String `tfsdk:"source_queue"`
+	SourceExchange         types.String `tfsdk:"source_exchange"`
+	SourceExchangeKey      types.String `tfsdk:"source_exchange_key"`
+	DestinationUri         types.String `tfsdk:"destination_uri"`
+	DestinationProtocol    types.String `tfsdk:"destination_protocol"`
+	DestinationQueue       types.String `tfsdk:"destination_queue"`
+	DestinationExchange    types.String `tfsdk:"destination_exchange"`
+	DestinationExchangeKey types.String `tfsdk:"destination_exchange_key"`
+	Reason                 types.String `tfsdk:"reason"`
+}
+
+type RabbitmqShovelStatusDataSourceModel struct {
+	Vhost   types.String                `tfsdk:"vhost"`
+	Name    types.String                `tfsdk:"name"`
+	State   types.String                `tfsdk:"state"`
+	Shovels []RabbitmqShovelStatusModel `tfsdk:"shovels"`
+}
+
+// rabbitmqShovelStatus is a /api/shovels entry. rabbit-hole's ShovelStatus
+// does not decode the node, endpoints or failure reason.
+type rabbitmqShovelStatus struct {
+	Name                   string          `json:"name"`
+	Vhost                  string          `json:"vhost"`
+	Type                   string          `json:"type"`
+	State                  string          `json:"state"`
+	Node                   string          `json:"node"`
+	Timestamp              string          `json:"timestamp"`
+	SourceUri              string          `json:"src_uri"`
+	SourceProtocol         string          `json:"src_protocol"`
+	SourceQueue            string          `json:"src_queue"`
+	SourceExchange         string          `json:"src_exchange"`
+	SourceExchangeKey      string          `json:"src_exchange_key"`
+	DestinationUri         string          `json:"dest_uri"`
+	DestinationProtocol    string          `json:"dest_protocol"`
+	DestinationQueue       string          `json:"dest_queue"`
+	DestinationExchange    string          `json:"dest_exchange"`
+	DestinationExchangeKey string          `json:"dest_exchange_key"`
+	Reason                 json.RawMessage `json:"reason"`
+}
+
+func (d *RabbitmqShovelStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqShovelStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shovel_status"
+}
+
+func (d *RabbitmqShovelStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list shovels in this vhost. Shovels in all vhosts visible to the provider user are listed when unset.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the shovel with this name.",
+			},
+			"state": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list shovels in this state, e.g. running, starting or terminated.",
+			},
+			"shovels": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching shovels.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the shovel.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the shovel.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the shovel, dynamic or static.",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state of the shovel, e.g. running, starting or terminated.",
+						},
+						"node": schema.StringAttribute{
+							Computed:    true,
+							Description: "The node the shovel runs on.",
+						},
+						"timestamp": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the shovel last changed state.",
+						},
+						"source_uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URI of the source, without its password.",
+						},
+						"source_protocol": schema.StringAttribute{
+							Computed:    true,
+							Description: "The protocol used to connect to the source, e.g. amqp091.",
+						},
+						"source_queue": schema.StringAttribute{
+							Computed:    true,
+							Description: "The source queue. Empty when the shovel consumes from an exchange.",
+						},
+						"source_exchange": schema.StringAttribute{
+							Computed:    true,
+							Description: "The source exchange. Empty when the shovel consumes from a queue.",
+						},
+						"source_exchange_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The routing key used to bind to the source exchange.",
+						},
+						"destination_uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URI of the destination, without its password.",
+						},
+						"destination_protocol": schema.StringAttribute{
+							Computed:    true,
+							Description: "The protocol used to connect to the destination, e.g. amqp091.",
+						},
+						"destination_queue": schema.StringAttribute{
+							Computed:    true,
+							Description: "The destination queue. Empty when the shovel publishes to an exchange.",
+						},
+						"destination_exchange": schema.StringAttribute{
+							Computed:    true,
+							Description: "The destination exchange. Empty when the shovel publishes to a queue.",
+						},
+						"destination_exchange_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The routing key used when publishing to the destination exchange.",
+						},
+						"reason": schema.StringAttribute{
+							Computed:    true,
+							Description: "Why the shovel terminated. Null when the broker reports no reason.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqShovelStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqShovelStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+	apiPath := "shovels"
+	if vhost != "" {
+		apiPath = "shovels/" + url.PathEscape(vhost)
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq shovel status", map[string]interface{}{
+		"vhost": vhost,
+	})
+
+	var shovels []rabbitmqShovelStatus
+	if err := d.providerData.getJSON(ctx, apiPath, &shovels); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Shovel Status",
+			fmt.Sprintf("Could not list RabbitMQ shovel status: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Shovels = []RabbitmqShovelStatusModel{}
+	for _, s := range shovels {
+		if !data.Name.IsNull() && s.Name != data.Name.ValueString() {
+			continue
+		}
+		if !data.State.IsNull() && s.State != data.State.ValueString() {
+			continue
+		}
+
+		data.Shovels = append(data.Shovels, RabbitmqShovelStatusModel{
+			Name:                   types.StringValue(s.Name),
+			Vhost:                  types.StringValue(s.Vhost),
+			Type:                   types.StringValue(s.Type),
+			State:                  types.StringValue(s.State),
+			Node:                   types.StringValue(s.Node),
+			Timestamp:              types.StringValue(s.Timestamp),
+			SourceUri:              types.StringValue(redactURIPassword(s.SourceUri)),
+			SourceProtocol:         types.StringValue(s.SourceProtocol),
+			SourceQueue:            types.StringValue(s.SourceQueue),
+			SourceExchange:         types.StringValue(s.SourceExchange),
+			SourceExchangeKey:      types.StringValue(s.SourceExchangeKey),
+			DestinationUri:         types.StringValue(redactURIPassword(s.DestinationUri)),
+			DestinationProtocol:    types.StringValue(s.DestinationProtocol),
+			DestinationQueue:       types.StringValue(s.DestinationQueue),
+			DestinationExchange:    types.StringValue(s.DestinationExchange),
+			DestinationExchangeKey: types.StringValue(s.DestinationExchangeKey),
+			Reason:                 rawReasonValue(s.Reason),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// redactURIPassword removes the password from a URI the broker reports, in
+// case the broker version does not already do so.
+func redactURIPassword(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+	if _, ok := u.User.Password(); !ok {
+		return uri
+	}
+
+	u.User = url.User(u.User.Username())
+	return u.String()
+}
+
+// rawReasonValue converts a failure reason reported by the broker. Reasons are
+// usually strings, but some plugins report formatted Erlang terms as JSON.
+func rawReasonValue(raw json.RawMessage) types.String {
+	if len(raw) == 0 || string(raw) == "null" {
+		return types.StringNull()
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return types.StringValue(s)
+	}
+
+	return types.StringValue(string(raw))
+}
@@ -0,0 +1,409 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqStreamConnectionsDataSource{}
+
+func NewRabbitmqStreamConnectionsDataSource() datasource.DataSource {
+	return &RabbitmqStreamConnectionsDataSource{}
+}
+
+type RabbitmqStreamConnectionsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqStreamConnectionModel struct {
+	Name        types.String `tfsdk:"name"`
+	Vhost       types.String `tfsdk:"vhost"`
+	User        types.String `tfsdk:"user"`
+	Node        types.String `tfsdk:"node"`
+	State       types.String `tfsdk:"state"`
+	PeerHost    types.String `tfsdk:"peer_host"`
+	PeerPort    types.Int64  `tfsdk:"peer_port"`
+	Publishers  types.Int64  `tfsdk:"publishers"`
+	Consumers   types.Int64  `tfsdk:"consumers"`
+	ConnectedAt types.String `tfsdk:"connected_at"`
+}
+
+type RabbitmqStreamPublisherModel struct {
+	ConnectionName    types.String `tfsdk:"connection_name"`
+	Vhost             types.String `tfsdk:"vhost"`
+	Stream            types.String `tfsdk:"stream"`
+	PublisherId       types.Int64  `tfsdk:"publisher_id"`
+	Reference         types.String `tfsdk:"reference"`
+	MessagesPublished types.Int64  `tfsdk:"messages_published"`
+	MessagesConfirmed types.Int64  `tfsdk:"messages_confirmed"`
+	MessagesErrored   types.Int64  `tfsdk:"messages_errored"`
+}
+
+type RabbitmqStreamConsumerModel struct {
+	ConnectionName   types.String `tfsdk:"connection_name"`
+	Vhost            types.String `tfsdk:"vhost"`
+	Stream           types.String `tfsdk:"stream"`
+	SubscriptionId   types.Int64  `tfsdk:"subscription_id"`
+	Credits          types.Int64  `tfsdk:"credits"`
+	MessagesConsumed types.Int64  `tfsdk:"messages_consumed"`
+	Offset           types.Int64  `tfsdk:"offset"`
+	OffsetLag        types.Int64  `tfsdk:"offset_lag"`
+	Active           types.Bool   `tfsdk:"active"`
+}
+
+type RabbitmqStreamConnectionsDataSourceModel struct {
+	Vhost        types.String                    `tfsdk:"vhost"`
+	Stream       types.String                    `tfsdk:"stream"`
+	MaxOffsetLag types.Int64                     `tfsdk:"max_offset_lag"`
+	Connections  []RabbitmqStreamConnectionModel `tfsdk:"connections"`
+	Publishers   []RabbitmqStreamPublisherModel  `tfsdk:"publishers"`
+	Consumers    []RabbitmqStreamConsumerModel   `tfsdk:"consumers"`
+}
+
+// rabbitmqStreamConnectionDetails is the connection_details object of a
+// stream publisher or consumer.
+type rabbitmqStreamConnectionDetails struct {
+	Name string `json:"name"`
+}
+
+// rabbitmqStreamQueue is the queue object of a stream publisher or consumer,
+// which names the stream.
+type rabbitmqStreamQueue struct {
+	Name  string `json:"name"`
+	Vhost string `json:"vhost"`
+}
+
+// rabbitmqStreamConnection is a /api/stream/connections entry.
+type rabbitmqStreamConnection struct {
+	Name        string          `json:"name"`
+	Vhost       string          `json:"vhost"`
+	User        string          `json:"user"`
+	Node        string          `json:"node"`
+	State       string          `json:"state"`
+	PeerHost    string          `json:"peer_host"`
+	PeerPort    rabbithole.Port `json:"peer_port"`
+	Publishers  int64           `json:"publishers"`
+	Consumers   int64           `json:"consumers"`
+	ConnectedAt int64           `json:"connected_at"`
+}
+
+// rabbitmqStreamPublisher is a /api/stream/publishers entry. rabbit-hole's
+// StreamPublisherInfo expects the stream and connection name at the top
+// level, while the broker nests them in queue and connection_details.
+type rabbitmqStreamPublisher struct {
+	ConnectionDetails rabbitmqStreamConnectionDetails `json:"connection_details"`
+	Queue             rabbitmqStreamQueue             `json:"queue"`
+	PublisherId       int64                           `json:"publisher_id"`
+	Reference         string                          `json:"reference"`
+	MessagesPublished int64                           `json:"messages_published"`
+	MessagesConfirmed int64                           `json:"messages_confirmed"`
+	MessagesErrored   int64                           `json:"messages_errored"`
+}
+
+// rabbitmqStreamConsumer is a /api/stream/consumers entry, see
+// rabbitmqStreamPublisher.
+type rabbitmqStreamConsumer struct {
+	ConnectionDetails rabbitmqStreamConnectionDetails `json:"connection_details"`
+	Queue             rabbitmqStreamQueue             `json:"queue"`
+	SubscriptionId    int64                           `json:"subscription_id"`
+	Credits           int64                           `json:"credits"`
+	MessagesConsumed  int64                           `json:"messages_consumed"`
+	Offset            int64                           `json:"offset"`
+	OffsetLag         int64                           `json:"offset_lag"`
+	Active            bool                            `json:"active"`
+}
+
+func (d *RabbitmqStreamConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqStreamConnectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stream_connections"
+}
+
+func (d *RabbitmqStreamConnectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"vhost": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list connections, publishers and consumers in this vhost. All vhosts visible to the provider user are listed when unset.",
+			},
+			"stream": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list publishers and consumers of this stream, and the connections they use.",
+			},
+			"max_offset_lag": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The largest offset lag among the matching consumers. 0 when there are none.",
+			},
+			"connections": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching stream protocol connections.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the connection.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the connection.",
+						},
+						"user": schema.StringAttribute{
+							Computed:    true,
+							Description: "The user the connection is authenticated as.",
+						},
+						"node": schema.StringAttribute{
+							Computed:    true,
+							Description: "The node the connection is open to.",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state of the connection, e.g. running.",
+						},
+						"peer_host": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address of the client.",
+						},
+						"peer_port": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The port of the client.",
+						},
+						"publishers": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of publishers on the connection.",
+						},
+						"consumers": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of consumers on the connection.",
+						},
+						"connected_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the connection was opened, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+			"publishers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching stream publishers.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"connection_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the connection the publisher uses.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the stream.",
+						},
+						"stream": schema.StringAttribute{
+							Computed:    true,
+							Description: "The stream the publisher publishes to.",
+						},
+						"publisher_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The id of the publisher on its connection.",
+						},
+						"reference": schema.StringAttribute{
+							Computed:    true,
+							Description: "The publisher reference used for deduplication. Empty when unset.",
+						},
+						"messages_published": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages published.",
+						},
+						"messages_confirmed": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages confirmed.",
+						},
+						"messages_errored": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages that failed.",
+						},
+					},
+				},
+			},
+			"consumers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching stream consumers.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"connection_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the connection the consumer uses.",
+						},
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost of the stream.",
+						},
+						"stream": schema.StringAttribute{
+							Computed:    true,
+							Description: "The stream the consumer reads from.",
+						},
+						"subscription_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The id of the subscription on its connection.",
+						},
+						"credits": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The credits the consumer has left.",
+						},
+						"messages_consumed": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of messages consumed.",
+						},
+						"offset": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The offset the consumer has read up to.",
+						},
+						"offset_lag": schema.Int64Attribute{
+							Computed:    true,
+							Description: "How far the consumer is behind the end of the stream, in offsets.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the consumer is active. Only one consumer of a single active consumer group is active.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqStreamConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqStreamConnectionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vhost := data.Vhost.ValueString()
+	stream := data.Stream.ValueString()
+	suffix := ""
+	if vhost != "" {
+		suffix = "/" + url.PathEscape(vhost)
+	}
+
+	tflog.Trace(ctx, "listing rabbitmq stream connections", map[string]interface{}{
+		"vhost":  vhost,
+		"stream": stream,
+	})
+
+	var connections []rabbitmqStreamConnection
+	if err := d.providerData.getJSON(ctx, "stream/connections"+suffix, &connections); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Stream Connections",
+			fmt.Sprintf("Could not list RabbitMQ stream connections, is the rabbitmq_stream_management plugin enabled? %s", err.Error()),
+		)
+		return
+	}
+
+	publishersPath := "stream/publishers" + suffix
+	if vhost != "" && stream != "" {
+		publishersPath += "/" + url.PathEscape(stream)
+	}
+	var publishers []rabbitmqStreamPublisher
+	if err := d.providerData.getJSON(ctx, publishersPath, &publishers); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Stream Publishers",
+			fmt.Sprintf("Could not list RabbitMQ stream publishers: %s", err.Error()),
+		)
+		return
+	}
+
+	var consumers []rabbitmqStreamConsumer
+	if err := d.providerData.getJSON(ctx, "stream/consumers"+suffix, &consumers); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RabbitMQ Stream Consumers",
+			fmt.Sprintf("Could not list RabbitMQ stream consumers: %s", err.Error()),
+		)
+		return
+	}
+
+	// With a stream filter, only the connections that publish to or consume
+	// from the stream are kept.
+	streamConnections := map[string]bool{}
+
+	data.Publishers = []RabbitmqStreamPublisherModel{}
+	for _, p := range publishers {
+		if stream != "" && p.Queue.Name != stream {
+			continue
+		}
+		streamConnections[p.ConnectionDetails.Name] = true
+		data.Publishers = append(data.Publishers, RabbitmqStreamPublisherModel{
+			ConnectionName:    types.StringValue(p.ConnectionDetails.Name),
+			Vhost:             types.StringValue(p.Queue.Vhost),
+			Stream:            types.StringValue(p.Queue.Name),
+			PublisherId:       types.Int64Value(p.PublisherId),
+			Reference:         types.StringValue(p.Reference),
+			MessagesPublished: types.Int64Value(p.MessagesPublished),
+			MessagesConfirmed: types.Int64Value(p.MessagesConfirmed),
+			MessagesErrored:   types.Int64Value(p.MessagesErrored),
+		})
+	}
+
+	var maxOffsetLag int64
+	data.Consumers = []RabbitmqStreamConsumerModel{}
+	for _, c := range consumers {
+		if stream != "" && c.Queue.Name != stream {
+			continue
+		}
+		streamConnections[c.ConnectionDetails.Name] = true
+		if c.OffsetLag > maxOffsetLag {
+			maxOffsetLag = c.OffsetLag
+		}
+		data.Consumers = append(data.Consumers, RabbitmqStreamConsumerModel{
+			ConnectionName:   types.StringValue(c.ConnectionDetails.Name),
+			Vhost:            types.StringValue(c.Queue.Vhost),
+			Stream:           types.StringValue(c.Queue.Name),
+			SubscriptionId:   types.Int64Value(c.SubscriptionId),
+			Credits:          types.Int64Value(c.Credits),
+			MessagesConsumed: types.Int64Value(c.MessagesConsumed),
+			Offset:           types.Int64Value(c.Offset),
+			OffsetLag:        types.Int64Value(c.OffsetLag),
+			Active:           types.BoolValue(c.Active),
+		})
+	}
+	data.MaxOffsetLag = types.Int64Value(maxOffsetLag)
+
+	data.Connections = []RabbitmqStreamConnectionModel{}
+	for _, c := range connections {
+		if stream != "" && !streamConnections[c.Name] {
+			continue
+		}
+
+		connectedAt := types.StringNull()
+		if c.ConnectedAt != 0 {
+			connectedAt = types.StringValue(time.UnixMilli(c.ConnectedAt).UTC().Format(time.RFC3339))
+		}
+
+		data.Connections = append(data.Connections, RabbitmqStreamConnectionModel{
+			Name:        types.StringValue(c.Name),
+			Vhost:       types.StringValue(c.Vhost),
+			User:        types.StringValue(c.User),
+			Node:        types.StringValue(c.Node),
+			State:       types.StringValue(c.State),
+			PeerHost:    types.StringValue(c.PeerHost),
+			PeerPort:    types.Int64Value(int64(c.PeerPort)),
+			Publishers:  types.Int64Value(c.Publishers),
+			Consumers:   types.Int64Value(c.Consumers),
+			ConnectedAt: connectedAt,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
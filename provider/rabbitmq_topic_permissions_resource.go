@@ -17,6 +17,7 @@ import (
 )
 
 var _ resource.Resource = &RabbitmqTopicPermissionsResource{}
+var _ resource.ResourceWithModifyPlan = &RabbitmqTopicPermissionsResource{}
 
 func NewRabbitmqTopicPermissionsResource() resource.Resource {
 	return &RabbitmqTopicPermissionsResource{}
@@ -59,10 +60,7 @@ func (r *RabbitmqTopicPermissionsResource) Schema(ctx context.Context, req resou
 			"vhost": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "The vhost to grant permissions for.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "The vhost to grant permissions for. Defaults to the default_vhost of the provider.",
 			},
 			"exchange": schema.StringAttribute{
 				Required:    true,
@@ -105,6 +103,10 @@ func (r *RabbitmqTopicPermissionsResource) ImportState(ctx context.Context, req
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
+func (r *RabbitmqTopicPermissionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planVhost(ctx, req, resp)
+}
+
 func (r *RabbitmqTopicPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan RabbitmqTopicPermissionsResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -113,8 +115,8 @@ func (r *RabbitmqTopicPermissionsResource) Create(ctx context.Context, req resou
 	}
 
 	user := plan.User.ValueString()
-	vhost := "/"
-	if !plan.Vhost.IsNull() {
+	vhost := r.providerData.defaultVhost
+	if !plan.Vhost.IsNull() && !plan.Vhost.IsUnknown() {
 		vhost = plan.Vhost.ValueString()
 	}
 	exchange := plan.Exchange.ValueString()
@@ -129,7 +131,7 @@ func (r *RabbitmqTopicPermissionsResource) Create(ctx context.Context, req resou
 		"exchange": exchange,
 	})
 
-	err := r.setTopicPermissions(user, vhost, exchange, &plan)
+	err := r.setTopicPermissions(ctx, user, vhost, exchange, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating RabbitMQ Topic Permissions",
@@ -158,7 +160,7 @@ func (r *RabbitmqTopicPermissionsResource) Read(ctx context.Context, req resourc
 		"exchange": exchange,
 	})
 
-	permissions, err := r.providerData.rabbitmqClient.GetTopicPermissionsIn(vhost, user)
+	permissions, err := r.providerData.client(ctx).GetTopicPermissionsIn(vhost, user)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading RabbitMQ Topic Permissions",
@@ -204,7 +206,7 @@ func (r *RabbitmqTopicPermissionsResource) Update(ctx context.Context, req resou
 		"exchange": exchange,
 	})
 
-	err := r.setTopicPermissions(user, vhost, exchange, &plan)
+	err := r.setTopicPermissions(ctx, user, vhost, exchange, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating RabbitMQ Topic Permissions",
@@ -233,7 +235,7 @@ func (r *RabbitmqTopicPermissionsResource) Delete(ctx context.Context, req resou
 		"exchange": exchange,
 	})
 
-	response, err := r.providerData.rabbitmqClient.ClearTopicPermissionsIn(vhost, user)
+	response, err := r.providerData.client(ctx).ClearTopicPermissionsIn(vhost, user)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting RabbitMQ Topic Permissions",
@@ -256,14 +258,14 @@ func (r *RabbitmqTopicPermissionsResource) Delete(ctx context.Context, req resou
 	}
 }
 
-func (r *RabbitmqTopicPermissionsResource) setTopicPermissions(user, vhost, exchange string, plan *RabbitmqTopicPermissionsResourceModel) error {
+func (r *RabbitmqTopicPermissionsResource) setTopicPermissions(ctx context.Context, user, vhost, exchange string, plan *RabbitmqTopicPermissionsResourceModel) error {
 	permissions := rabbithole.TopicPermissions{
 		Exchange: exchange,
 		Write:    plan.Write.ValueString(),
 		Read:     plan.Read.ValueString(),
 	}
 
-	response, err := r.providerData.rabbitmqClient.UpdateTopicPermissionsIn(vhost, user, permissions)
+	response, err := r.providerData.client(ctx).UpdateTopicPermissionsIn(vhost, user, permissions)
 	if err != nil {
 		return err
 	}
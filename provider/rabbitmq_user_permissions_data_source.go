@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqUserPermissionsDataSource{}
+
+func NewRabbitmqUserPermissionsDataSource() datasource.DataSource {
+	return &RabbitmqUserPermissionsDataSource{}
+}
+
+type RabbitmqUserPermissionsDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqUserTopicPermissionsModel struct {
+	Exchange types.String `tfsdk:"exchange"`
+	Write    types.String `tfsdk:"write"`
+	Read     types.String `tfsdk:"read"`
+}
+
+type RabbitmqUserVhostPermissionsModel struct {
+	Vhost            types.String                        `tfsdk:"vhost"`
+	Configure        types.String                        `tfsdk:"configure"`
+	Write            types.String                        `tfsdk:"write"`
+	Read             types.String                        `tfsdk:"read"`
+	TopicPermissions []RabbitmqUserTopicPermissionsModel `tfsdk:"topic_permissions"`
+}
+
+type RabbitmqUserPermissionsDataSourceModel struct {
+	User        types.String                        `tfsdk:"user"`
+	Permissions []RabbitmqUserVhostPermissionsModel `tfsdk:"permissions"`
+}
+
+func (d *RabbitmqUserPermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqUserPermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_permissions"
+}
+
+func (d *RabbitmqUserPermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				Required:    true,
+				Description: "The user to read permissions for.",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The permissions of the user, one entry per vhost, sorted by vhost.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"vhost": schema.StringAttribute{
+							Computed:    true,
+							Description: "The vhost the permissions apply to.",
+						},
+						"configure": schema.StringAttribute{
+							Computed:    true,
+							Description: "The configure permissions. Null when the user only has topic permissions in the vhost.",
+						},
+						"write": schema.StringAttribute{
+							Computed:    true,
+							Description: "The write permissions. Null when the user only has topic permissions in the vhost.",
+						},
+						"read": schema.StringAttribute{
+							Computed:    true,
+							Description: "The read permissions. Null when the user only has topic permissions in the vhost.",
+						},
+						"topic_permissions": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The topic permissions in the vhost, sorted by exchange.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"exchange": schema.StringAttribute{
+										Computed:    true,
+										Description: "The exchange the topic permissions apply to.",
+									},
+									"write": schema.StringAttribute{
+										Computed:    true,
+										Description: "The write topic permissions.",
+									},
+									"read": schema.StringAttribute{
+										Computed:    true,
+										Description: "The read topic permissions.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RabbitmqUserPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqUserPermissionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user := data.User.ValueString()
+
+	tflog.Trace(ctx, "reading rabbitmq user permissions", map[string]interface{}{
+		"user": user,
+	})
+
+	rmqc := d.providerData.client(ctx)
+	permissions, err := rmqc.ListPermissionsOf(user)
+	if err != nil {
+		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
+			resp.Diagnostics.AddError(
+				"RabbitMQ User Not Found",
+				fmt.Sprintf("RabbitMQ user %s does not exist.", user),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading RabbitMQ Permissions",
+			fmt.Sprintf("Could not read RabbitMQ permissions for user %s: %s", user, err.Error()),
+		)
+		return
+	}
+
+	topicPermissions, err := rmqc.ListTopicPermissionsOf(user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RabbitMQ Topic Permissions",
+			fmt.Sprintf("Could not read RabbitMQ topic permissions for user %s: %s", user, err.Error()),
+		)
+		return
+	}
+
+	byVhost := map[string]*RabbitmqUserVhostPermissionsModel{}
+	entry := func(vhost string) *RabbitmqUserVhostPermissionsModel {
+		if e, ok := byVhost[vhost]; ok {
+			return e
+		}
+		e := &RabbitmqUserVhostPermissionsModel{
+			Vhost:            types.StringValue(vhost),
+			Configure:        types.StringNull(),
+			Write:            types.StringNull(),
+			Read:             types.StringNull(),
+			TopicPermissions: []RabbitmqUserTopicPermissionsModel{},
+		}
+		byVhost[vhost] = e
+		return e
+	}
+
+	for _, p := range permissions {
+		e := entry(p.Vhost)
+		e.Configure = types.StringValue(p.Configure)
+		e.Write = types.StringValue(p.Write)
+		e.Read = types.StringValue(p.Read)
+	}
+
+	sort.Slice(topicPermissions, func(i, j int) bool {
+		return topicPermissions[i].Exchange < topicPermissions[j].Exchange
+	})
+	for _, p := range topicPermissions {
+		e := entry(p.Vhost)
+		e.TopicPermissions = append(e.TopicPermissions, RabbitmqUserTopicPermissionsModel{
+			Exchange: types.StringValue(p.Exchange),
+			Write:    types.StringValue(p.Write),
+			Read:     types.StringValue(p.Read),
+		})
+	}
+
+	vhosts := make([]string, 0, len(byVhost))
+	for vhost := range byVhost {
+		vhosts = append(vhosts, vhost)
+	}
+	sort.Strings(vhosts)
+
+	data.Permissions = make([]RabbitmqUserVhostPermissionsModel, 0, len(vhosts))
+	for _, vhost := range vhosts {
+		data.Permissions = append(data.Permissions, *byVhost[vhost])
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
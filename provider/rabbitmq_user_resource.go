@@ -94,7 +94,7 @@ func (r *RabbitmqUserResource) Create(ctx context.Context, req resource.CreateRe
 	tflog.Trace(ctx, "creating rabbitmq user", map[string]interface{}{
 		"user": plan.Name.ValueString(),
 	})
-	err := r.CreateUser(name, password, tags)
+	err := r.CreateUser(ctx, name, password, tags)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating RabbitMQ User",
@@ -117,7 +117,7 @@ func (r *RabbitmqUserResource) Read(ctx context.Context, req resource.ReadReques
 	tflog.Trace(ctx, "reading rabbitmq user", map[string]interface{}{
 		"user": name,
 	})
-	user, err := r.ReadUser(name)
+	user, err := r.ReadUser(ctx, name)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading RabbitMQ User",
@@ -151,7 +151,7 @@ func (r *RabbitmqUserResource) Update(ctx context.Context, req resource.UpdateRe
 	tflog.Trace(ctx, "reading rabbitmq user", map[string]interface{}{
 		"user": name,
 	})
-	user, err := r.ReadUser(name)
+	user, err := r.ReadUser(ctx, name)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading RabbitMQ User",
@@ -164,7 +164,7 @@ func (r *RabbitmqUserResource) Update(ctx context.Context, req resource.UpdateRe
 		"user": name,
 	})
 
-	err = r.UpdateUser(user, password, tags)
+	err = r.UpdateUser(ctx, user, password, tags)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating RabbitMQ User",
@@ -186,7 +186,7 @@ func (r *RabbitmqUserResource) Delete(ctx context.Context, req resource.DeleteRe
 	tflog.Trace(ctx, "deleting rabbitmq user", map[string]interface{}{
 		"user": name,
 	})
-	err := r.DeleteUser(name)
+	err := r.DeleteUser(ctx, name)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting RabbitMQ User",
@@ -196,8 +196,8 @@ func (r *RabbitmqUserResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
-func (r *RabbitmqUserResource) CreateUser(name string, password string, tags []string) error {
-	rmqc := r.providerData.rabbitmqClient
+func (r *RabbitmqUserResource) CreateUser(ctx context.Context, name string, password string, tags []string) error {
+	rmqc := r.providerData.client(ctx)
 
 	userSettings := rabbithole.UserSettings{
 		Password: password,
@@ -216,8 +216,8 @@ func (r *RabbitmqUserResource) CreateUser(name string, password string, tags []s
 	return nil
 }
 
-func (r *RabbitmqUserResource) ReadUser(name string) (*rabbithole.UserInfo, error) {
-	rmqc := r.providerData.rabbitmqClient
+func (r *RabbitmqUserResource) ReadUser(ctx context.Context, name string) (*rabbithole.UserInfo, error) {
+	rmqc := r.providerData.client(ctx)
 
 	user, err := rmqc.GetUser(name)
 	if err != nil {
@@ -246,8 +246,8 @@ func (r *RabbitmqUserResource) LoadUserIntoState(model *RabbitmqUserResourceMode
 	}
 }
 
-func (r *RabbitmqUserResource) UpdateUser(user *rabbithole.UserInfo, newPassword string, newTags []string) error {
-	rmqc := r.providerData.rabbitmqClient
+func (r *RabbitmqUserResource) UpdateUser(ctx context.Context, user *rabbithole.UserInfo, newPassword string, newTags []string) error {
+	rmqc := r.providerData.client(ctx)
 
 	userSettings := rabbithole.UserSettings{
 		PasswordHash:     user.PasswordHash,
@@ -280,8 +280,8 @@ func (r *RabbitmqUserResource) UpdateUser(user *rabbithole.UserInfo, newPassword
 	return nil
 }
 
-func (r *RabbitmqUserResource) DeleteUser(name string) error {
-	rmqc := r.providerData.rabbitmqClient
+func (r *RabbitmqUserResource) DeleteUser(ctx context.Context, name string) error {
+	rmqc := r.providerData.client(ctx)
 
 	resp, err := rmqc.DeleteUser(name)
 	if err != nil {
@@ -109,7 +109,7 @@ func (r *RabbitmqVhostResource) Create(ctx context.Context, req resource.CreateR
 		"name": name,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	tags := []string{}
 	if !plan.Tags.IsNull() && !plan.Tags.IsUnknown() {
 		plan.Tags.ElementsAs(ctx, &tags, false)
@@ -155,7 +155,7 @@ func (r *RabbitmqVhostResource) Read(ctx context.Context, req resource.ReadReque
 		"name": name,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	vhost, err := rmqc.GetVhost(name)
 	if err != nil {
 		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
@@ -219,7 +219,7 @@ func (r *RabbitmqVhostResource) Update(ctx context.Context, req resource.UpdateR
 		"name": name,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	tags := []string{}
 	if !plan.Tags.IsNull() && !plan.Tags.IsUnknown() {
 		plan.Tags.ElementsAs(ctx, &tags, false)
@@ -262,7 +262,7 @@ func (r *RabbitmqVhostResource) Delete(ctx context.Context, req resource.DeleteR
 		"name": name,
 	})
 
-	rmqc := r.providerData.rabbitmqClient
+	rmqc := r.providerData.client(ctx)
 	response, err := rmqc.DeleteVhost(name)
 	if err != nil {
 		if rabbitErr, ok := err.(rabbithole.ErrorResponse); ok && rabbitErr.StatusCode == 404 {
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RabbitmqWhoamiDataSource{}
+
+func NewRabbitmqWhoamiDataSource() datasource.DataSource {
+	return &RabbitmqWhoamiDataSource{}
+}
+
+type RabbitmqWhoamiDataSource struct {
+	providerData *RabbitmqProviderData
+}
+
+type RabbitmqWhoamiDataSourceModel struct {
+	Name            types.String `tfsdk:"name"`
+	Tags            types.List   `tfsdk:"tags"`
+	AuthBackend     types.String `tfsdk:"auth_backend"`
+	IsAdministrator types.Bool   `tfsdk:"is_administrator"`
+}
+
+func (d *RabbitmqWhoamiDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.providerData = req.ProviderData.(*RabbitmqProviderData)
+}
+
+func (d *RabbitmqWhoamiDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+func (d *RabbitmqWhoamiDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the user the provider is authenticated as.",
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The tags of the user.",
+			},
+			"auth_backend": schema.StringAttribute{
+				Computed:    true,
+				Description: "The authentication backend that authenticated the user.",
+			},
+			"is_administrator": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the user has the administrator tag.",
+			},
+		},
+	}
+}
+
+func (d *RabbitmqWhoamiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqWhoamiDataSourceModel
+
+	tflog.Trace(ctx, "reading rabbitmq whoami")
+
+	whoami, err := d.providerData.client(ctx).Whoami()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading RabbitMQ Identity",
+			fmt.Sprintf("Could not read the identity of the provider user: %s", err.Error()),
+		)
+		return
+	}
+
+	tags := []attr.Value{}
+	for _, v := range whoami.Tags {
+		if v != "" {
+			tags = append(tags, types.StringValue(v))
+		}
+	}
+
+	data.Name = types.StringValue(whoami.Name)
+	data.Tags = types.ListValueMust(types.StringType, tags)
+	data.AuthBackend = types.StringValue(whoami.AuthBackend)
+	data.IsAdministrator = types.BoolValue(slices.Contains(whoami.Tags, "administrator"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
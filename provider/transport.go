@@ -0,0 +1,546 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before its expiry an OAuth 2.0 token is
+// replaced, so that it does not expire while a request is in flight.
+const tokenRefreshMargin = time.Minute
+
+// tokenSource provides the bearer token sent with every request.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticToken is a bearer token set in the provider configuration.
+type staticToken string
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// oauth2TokenSource fetches tokens with the OAuth 2.0 client credentials
+// grant and caches them until shortly before they expire.
+type oauth2TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	client       *http.Client
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+type oauth2TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *oauth2TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.refreshAt.IsZero() || time.Now().Before(s.refreshAt)) {
+		return s.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("could not request an OAuth 2.0 token from %s: %w", s.tokenURL, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	issuedAt := time.Now()
+	res, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not request an OAuth 2.0 token from %s: %w", s.tokenURL, err)
+	}
+	defer res.Body.Close()
+
+	var tok oauth2TokenResponse
+	decodeErr := json.NewDecoder(res.Body).Decode(&tok)
+	if res.StatusCode >= 400 {
+		if tok.ErrorDescription != "" {
+			return "", fmt.Errorf("OAuth 2.0 token request to %s failed with %s: %s: %s", s.tokenURL, res.Status, tok.Error, tok.ErrorDescription)
+		}
+		if tok.Error != "" {
+			return "", fmt.Errorf("OAuth 2.0 token request to %s failed with %s: %s", s.tokenURL, res.Status, tok.Error)
+		}
+		return "", fmt.Errorf("OAuth 2.0 token request to %s failed with %s", s.tokenURL, res.Status)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("could not decode the OAuth 2.0 token response from %s: %w", s.tokenURL, decodeErr)
+	}
+	if tok.AccessToken == "" {
+		return "", fmt.Errorf("the OAuth 2.0 token response from %s has no access_token", s.tokenURL)
+	}
+	if tok.TokenType != "" && !strings.EqualFold(tok.TokenType, "bearer") {
+		return "", fmt.Errorf("the OAuth 2.0 token response from %s has token_type %q, expected Bearer", s.tokenURL, tok.TokenType)
+	}
+
+	s.token = tok.AccessToken
+	s.refreshAt = time.Time{}
+	if tok.ExpiresIn > 0 {
+		lifetime := time.Duration(tok.ExpiresIn) * time.Second
+		margin := tokenRefreshMargin
+		if margin > lifetime/2 {
+			margin = lifetime / 2
+		}
+		s.refreshAt = issuedAt.Add(lifetime - margin)
+	}
+
+	return s.token, nil
+}
+
+// headerTransport adds the configured headers to every request.
+type headerTransport struct {
+	base   http.RoundTripper
+	header http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+// validHeaderName reports whether name is a valid HTTP header field name, a
+// token as defined by RFC 9110.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// bearerTransport sets the Authorization header of every request to a token
+// from source, replacing the basic auth credentials rabbit-hole sets.
+type bearerTransport struct {
+	base   http.RoundTripper
+	source tokenSource
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+// failoverTransport sends requests to one of several management endpoints.
+// Requests are built against the first endpoint and rewritten to the endpoint
+// in use. When that endpoint cannot be reached, or answers an idempotent
+// request other than a health check with a 5xx status, the next endpoint is
+// tried, and the first one to answer stays in use for later requests.
+type failoverTransport struct {
+	base      http.RoundTripper
+	endpoints []*url.URL
+
+	mu     sync.Mutex
+	active int
+}
+
+func newFailoverTransport(base http.RoundTripper, endpoints []string) (*failoverTransport, error) {
+	t := &failoverTransport{base: base}
+	for _, e := range endpoints {
+		u, err := url.Parse(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", e, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q: expected a URL such as http://localhost:15672", e)
+		}
+		t.endpoints = append(t.endpoints, u)
+	}
+	return t, nil
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	active := t.active
+	t.mu.Unlock()
+
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	healthCheck := isHealthCheck(req)
+	retry5xx := replayable && isIdempotent(req.Method) && !healthCheck
+
+	var failures []string
+	for i := range t.endpoints {
+		n := (active + i) % len(t.endpoints)
+		endpoint := t.endpoints[n]
+
+		attempt := req.Clone(req.Context())
+		attempt.URL = t.rewrite(req.URL, endpoint)
+		attempt.Host = ""
+		if i > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		res, err := t.base.RoundTrip(attempt)
+		last := i == len(t.endpoints)-1 || !replayable
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", endpoint.Redacted(), err.Error()))
+			if last {
+				return nil, fmt.Errorf("no RabbitMQ endpoint answered: %s", strings.Join(failures, "; "))
+			}
+			continue
+		}
+		if res.StatusCode >= 500 && retry5xx {
+			failures = append(failures, fmt.Sprintf("%s: %s", endpoint.Redacted(), res.Status))
+			if !last {
+				res.Body.Close()
+				continue
+			}
+			if len(failures) > 1 {
+				res.Body.Close()
+				return nil, fmt.Errorf("no RabbitMQ endpoint answered: %s", strings.Join(failures, "; "))
+			}
+		}
+
+		if n != active {
+			t.mu.Lock()
+			t.active = n
+			t.mu.Unlock()
+		}
+		if res.StatusCode >= 400 && !healthCheck {
+			annotateErrorResponse(res, endpoint)
+		}
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("no RabbitMQ endpoint answered: %s", strings.Join(failures, "; "))
+}
+
+// rewrite moves a URL built against the first endpoint to endpoint.
+func (t *failoverTransport) rewrite(u *url.URL, endpoint *url.URL) *url.URL {
+	primary := t.endpoints[0]
+
+	rewritten := *u
+	rewritten.Scheme = endpoint.Scheme
+	rewritten.Host = endpoint.Host
+	rewritten.User = endpoint.User
+	rewritten.Path = endpoint.Path + strings.TrimPrefix(u.Path, primary.Path)
+	if u.RawPath != "" {
+		rewritten.RawPath = endpoint.EscapedPath() + strings.TrimPrefix(u.RawPath, primary.EscapedPath())
+	}
+	return &rewritten
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isHealthCheck reports whether req runs a health check. Health checks answer
+// 503 with their result when they fail, which is not a sign of an unhealthy
+// endpoint, and most of them check the node that answers.
+func isHealthCheck(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, "/api/health/checks/")
+}
+
+// annotateErrorResponse adds the endpoint that answered to the reason of an
+// error response, so that diagnostics built from it name the endpoint.
+func annotateErrorResponse(res *http.Response, endpoint *url.URL) {
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var rme map[string]interface{}
+	if err := json.Unmarshal(body, &rme); err != nil {
+		return
+	}
+	reason, _ := rme["reason"].(string)
+	if reason == "" {
+		rme["reason"] = fmt.Sprintf("answered by %s", endpoint.Redacted())
+	} else {
+		rme["reason"] = fmt.Sprintf("%s (answered by %s)", reason, endpoint.Redacted())
+	}
+
+	annotated, err := json.Marshal(rme)
+	if err != nil {
+		return
+	}
+	res.Body = io.NopCloser(bytes.NewReader(annotated))
+	res.ContentLength = int64(len(annotated))
+	res.Header.Del("Content-Length")
+}
+
+// retryTransport retries idempotent requests that fail with a transport error
+// or a retryable status, waiting an exponentially growing, jittered backoff
+// between attempts. Health checks are not retried, as they answer 503 when
+// they fail.
+type retryTransport struct {
+	base        http.RoundTripper
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	statusCodes map[int]bool
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if t.maxAttempts <= 1 || !replayable || !isIdempotent(req.Method) || isHealthCheck(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		try := req
+		if attempt > 1 {
+			try = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				try.Body = body
+			}
+		}
+
+		res, err := t.base.RoundTrip(try)
+		if attempt >= t.maxAttempts || req.Context().Err() != nil {
+			return res, err
+		}
+		if err == nil && !t.statusCodes[res.StatusCode] {
+			return res, nil
+		}
+
+		wait := t.backoff(attempt)
+		if res != nil {
+			if retryAfter := parseRetryAfter(res.Header.Get("Retry-After")); retryAfter > wait {
+				wait = min(retryAfter, t.maxBackoff)
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait after the given failed attempt: between
+// half and all of baseBackoff doubled for each earlier attempt, capped at
+// maxBackoff.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.baseBackoff
+	for i := 1; i < attempt && d < t.maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, t.maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds. HTTP dates
+// are not used by the management API and are ignored.
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// unconfiguredTransport fails every request of a provider left unconfigured
+// because its configuration is unknown until apply.
+type unconfiguredTransport struct{}
+
+func (unconfiguredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, errors.New("the RabbitMQ provider is not configured, as its configuration depends on values unknown until apply")
+}
+
+// contextTransport ties requests to ctx, so that they are cancelled when
+// Terraform cancels the operation. rabbit-hole builds its requests without a
+// context.
+type contextTransport struct {
+	base http.RoundTripper
+	ctx  context.Context
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// timeoutTransport bounds each attempt of a request, from sending it until
+// its response body is closed.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			err = fmt.Errorf("no response within the request timeout of %s: %w", t.timeout, err)
+		}
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// limitTransport bounds how many requests are in flight at once and how many
+// are started per second. One limitTransport is shared by every resource and
+// data source of a provider instance.
+//
+// A request holds its slot until its response headers arrive rather than
+// until its body is closed, as rabbit-hole leaves the bodies of PUT and
+// DELETE responses open.
+type limitTransport struct {
+	base     http.RoundTripper
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newLimitTransport(base http.RoundTripper, maxConcurrent int, perSecond float64) *limitTransport {
+	t := &limitTransport{base: base}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.interval > 0 {
+		if err := t.wait(ctx); err != nil {
+			t.release()
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+
+	defer t.release()
+	return t.base.RoundTrip(req)
+}
+
+// wait blocks until the next request may start under the requests per second
+// limit, reserving that start time.
+func (t *limitTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *limitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
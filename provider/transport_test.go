@@ -0,0 +1,710 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTokenServer starts a stand-in OAuth 2.0 token endpoint that issues
+// numbered tokens valid for expiresIn seconds.
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing token request: %s", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", got)
+		}
+		if got := r.PostForm.Get("scope"); got != "rabbitmq.read:*/* rabbitmq.write:*/*" {
+			t.Errorf("scope = %q", got)
+		}
+		if id, secret, _ := r.BasicAuth(); id != "terraform" || secret != "s3cret" {
+			t.Errorf("client credentials = %q, %q", id, secret)
+		}
+
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &issued
+}
+
+func newTestTokenSource(tokenURL string) *oauth2TokenSource {
+	return &oauth2TokenSource{
+		tokenURL:     tokenURL,
+		clientID:     "terraform",
+		clientSecret: "s3cret",
+		scopes:       []string{"rabbitmq.read:*/*", "rabbitmq.write:*/*"},
+		client:       &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func TestOAuth2TokenSourceCachesToken(t *testing.T) {
+	srv, issued := newTokenServer(t, 3600)
+	source := newTestTokenSource(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "token-1" {
+			t.Errorf("token = %q, want token-1", token)
+		}
+	}
+	if n := issued.Load(); n != 1 {
+		t.Errorf("issued %d tokens, want 1", n)
+	}
+}
+
+func TestOAuth2TokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	// A token valid for 2s is refreshed after half its lifetime.
+	srv, issued := newTokenServer(t, 2)
+	source := newTestTokenSource(srv.URL)
+
+	if token, err := source.Token(t.Context()); err != nil || token != "token-1" {
+		t.Fatalf("first token = %q, %v", token, err)
+	}
+	if token, err := source.Token(t.Context()); err != nil || token != "token-1" {
+		t.Fatalf("cached token = %q, %v", token, err)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	if token, err := source.Token(t.Context()); err != nil || token != "token-2" {
+		t.Fatalf("refreshed token = %q, %v", token, err)
+	}
+	if n := issued.Load(); n != 2 {
+		t.Errorf("issued %d tokens, want 2", n)
+	}
+}
+
+func TestOAuth2TokenSourceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "error with description",
+			status:  http.StatusUnauthorized,
+			body:    `{"error":"invalid_client","error_description":"unknown client"}`,
+			wantErr: "failed with 401 Unauthorized: invalid_client: unknown client",
+		},
+		{
+			name:    "error without description",
+			status:  http.StatusBadRequest,
+			body:    `{"error":"invalid_scope"}`,
+			wantErr: "failed with 400 Bad Request: invalid_scope",
+		},
+		{
+			name:    "error without body",
+			status:  http.StatusInternalServerError,
+			body:    ``,
+			wantErr: "failed with 500 Internal Server Error",
+		},
+		{
+			name:    "no access token",
+			status:  http.StatusOK,
+			body:    `{"token_type":"Bearer","expires_in":60}`,
+			wantErr: "has no access_token",
+		},
+		{
+			name:    "not a bearer token",
+			status:  http.StatusOK,
+			body:    `{"access_token":"abc","token_type":"mac"}`,
+			wantErr: `has token_type "mac", expected Bearer`,
+		},
+		{
+			name:    "invalid JSON",
+			status:  http.StatusOK,
+			body:    `<html>`,
+			wantErr: "could not decode the OAuth 2.0 token response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			_, err := newTestTokenSource(srv.URL).Token(t.Context())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBearerTransportReplacesBasicAuth(t *testing.T) {
+	tokenSrv, _ := newTokenServer(t, 3600)
+
+	var authorization atomic.Value
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"name":"terraform","tags":["administrator"]}`)
+	}))
+	defer api.Close()
+
+	transport := &bearerTransport{base: http.DefaultTransport, source: newTestTokenSource(tokenSrv.URL)}
+	rmqc, err := rabbithole.NewTLSClient(api.URL, "guest", "guest", transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rmqc.Whoami(); err != nil {
+		t.Fatal(err)
+	}
+	if got := authorization.Load(); got != "Bearer token-1" {
+		t.Errorf("Authorization = %q, want Bearer token-1", got)
+	}
+}
+
+func TestBearerTransportTokenError(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"invalid_client"}`)
+	}))
+	defer tokenSrv.Close()
+
+	var requests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer api.Close()
+
+	transport := &bearerTransport{base: http.DefaultTransport, source: newTestTokenSource(tokenSrv.URL)}
+	rmqc, err := rabbithole.NewTLSClient(api.URL, "guest", "guest", transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rmqc.Whoami(); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("error = %v, want the token error", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("sent %d requests without a token, want 0", n)
+	}
+}
+
+// newFailoverClient returns a rabbit-hole client that fails over between the
+// given endpoints.
+func newFailoverClient(t *testing.T, endpoints ...string) (*rabbithole.Client, *failoverTransport) {
+	t.Helper()
+
+	failover, err := newFailoverTransport(http.DefaultTransport, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rmqc, err := rabbithole.NewTLSClient(endpoints[0], "guest", "guest", failover)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rmqc, failover
+}
+
+func TestFailoverTransportUnreachableEndpoint(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var requests atomic.Int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"name":"guest","tags":[]}`)
+	}))
+	defer up.Close()
+
+	rmqc, failover := newFailoverClient(t, down.URL, up.URL)
+	for i := 0; i < 2; i++ {
+		if _, err := rmqc.Whoami(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if failover.active != 1 {
+		t.Errorf("active endpoint = %d, want 1", failover.active)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("second endpoint got %d requests, want 2", n)
+	}
+}
+
+func TestFailoverTransportServerError(t *testing.T) {
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"guest","tags":[]}`)
+	}))
+	defer up.Close()
+
+	rmqc, failover := newFailoverClient(t, unavailable.URL, up.URL)
+	if _, err := rmqc.Whoami(); err != nil {
+		t.Fatal(err)
+	}
+	if failover.active != 1 {
+		t.Errorf("active endpoint = %d, want 1", failover.active)
+	}
+}
+
+func TestFailoverTransportNoEndpointAnswers(t *testing.T) {
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer unavailable.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	rmqc, _ := newFailoverClient(t, unavailable.URL, down.URL)
+	_, err := rmqc.Whoami()
+	if err == nil || !strings.Contains(err.Error(), "no RabbitMQ endpoint answered") ||
+		!strings.Contains(err.Error(), unavailable.URL+": 502 Bad Gateway") || !strings.Contains(err.Error(), down.URL) {
+		t.Errorf("error = %v, want every endpoint listed", err)
+	}
+}
+
+func TestFailoverTransportAnnotatesErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Object Not Found","reason":"Not Found"}`)
+	}))
+	defer srv.Close()
+
+	rmqc, _ := newFailoverClient(t, srv.URL, "http://127.0.0.1:1")
+	_, err := rmqc.GetVhost("missing")
+	rerr, ok := err.(rabbithole.ErrorResponse)
+	if !ok {
+		t.Fatalf("error = %#v, want an ErrorResponse", err)
+	}
+	if want := "Not Found (answered by " + srv.URL + ")"; rerr.Reason != want {
+		t.Errorf("reason = %q, want %q", rerr.Reason, want)
+	}
+}
+
+func TestFailoverTransportHealthCheckFailure(t *testing.T) {
+	var alarmed, healthy atomic.Int32
+	alarms := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alarmed.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"status":"failed","reason":"There are alarms in effect in the cluster","alarms":[]}`)
+	}))
+	defer alarms.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy.Add(1)
+		fmt.Fprint(w, `{"status":"ok"}`)
+	}))
+	defer up.Close()
+
+	rmqc, failover := newFailoverClient(t, alarms.URL, up.URL)
+	rec, err := rmqc.HealthCheckLocalAlarms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Status != "failed" || rec.Reason != "There are alarms in effect in the cluster" {
+		t.Errorf("result = %+v, want the failure of the first endpoint", rec)
+	}
+	if failover.active != 0 {
+		t.Errorf("active endpoint = %d, want 0", failover.active)
+	}
+	if alarmed.Load() != 1 || healthy.Load() != 0 {
+		t.Errorf("requests = %d, %d, want 1, 0", alarmed.Load(), healthy.Load())
+	}
+}
+
+func newTestRetryTransport(base http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		base:        base,
+		maxAttempts: 3,
+		baseBackoff: time.Millisecond,
+		maxBackoff:  10 * time.Millisecond,
+		statusCodes: map[int]bool{502: true, 503: true, 504: true},
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"tracing":true`) {
+			t.Errorf("attempt %d body = %q", attempts.Load()+1, body)
+		}
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	rmqc, err := rabbithole.NewTLSClient(srv.URL, "guest", "guest", newTestRetryTransport(http.DefaultTransport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := rmqc.PutVhost("tenant", rabbithole.VhostSettings{Tracing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want 201", res.StatusCode)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("attempts = %d, want 3", n)
+	}
+}
+
+func TestRetryTransportAttempts(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		want   int32
+	}{
+		{name: "gives up after max attempts", method: http.MethodGet, path: "/api/overview", status: http.StatusBadGateway, want: 3},
+		{name: "status not retryable", method: http.MethodGet, path: "/api/overview", status: http.StatusInternalServerError, want: 1},
+		{name: "POST not retried", method: http.MethodPost, path: "/api/definitions", status: http.StatusServiceUnavailable, want: 1},
+		{name: "health check not retried", method: http.MethodGet, path: "/api/health/checks/local-alarms", status: http.StatusServiceUnavailable, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := newTestRetryTransport(http.DefaultTransport).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.status)
+			}
+			if n := attempts.Load(); n != tt.want {
+				t.Errorf("attempts = %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryTransportStopsOnCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	transport := newTestRetryTransport(http.DefaultTransport)
+	transport.maxBackoff = time.Minute
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/overview", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = transport.RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, want right after the context ended", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		" 10 ":                          10 * time.Second,
+		"-1":                            0,
+		"Wed, 21 Oct 2015 07:28:00 GMT": 0,
+	}
+	for v, want := range tests {
+		if got := parseRetryAfter(v); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+func TestLimitTransportWritesWithOpenBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	rmqc, err := rabbithole.NewTLSClient(srv.URL, "guest", "guest", newLimitTransport(http.DefaultTransport, 2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rmqc.SetTimeout(5 * time.Second)
+
+	// Resources do not close the bodies of PUT and DELETE responses.
+	for i := 0; i < 5; i++ {
+		if _, err := rmqc.PutVhost(fmt.Sprintf("tenant-%d", i), rabbithole.VhostSettings{}); err != nil {
+			t.Fatalf("write %d: %s", i, err)
+		}
+		if _, err := rmqc.DeleteVhost(fmt.Sprintf("tenant-%d", i)); err != nil {
+			t.Fatalf("delete %d: %s", i, err)
+		}
+	}
+}
+
+func TestLimitTransportConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"name":"guest","tags":[]}`)
+	}))
+	defer srv.Close()
+
+	rmqc, err := rabbithole.NewTLSClient(srv.URL, "guest", "guest", newLimitTransport(http.DefaultTransport, 3, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := rmqc.Whoami(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", p)
+	}
+}
+
+func TestLimitTransportRate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"guest","tags":[]}`)
+	}))
+	defer srv.Close()
+
+	rmqc, err := rabbithole.NewTLSClient(srv.URL, "guest", "guest", newLimitTransport(http.DefaultTransport, 0, 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// At 50 requests per second, 6 requests take at least 5 intervals of 20ms.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := rmqc.Whoami(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("6 requests took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestLimitTransportCancelWhileWaiting(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	transport := newLimitTransport(http.DefaultTransport, 1, 0)
+	go func() {
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		if res, err := transport.RoundTrip(req); err == nil {
+			res.Body.Close()
+		}
+	}()
+	for len(transport.slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	transport := &timeoutTransport{base: http.DefaultTransport, timeout: 50 * time.Millisecond}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = transport.RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "no response within the request timeout of 50ms") {
+		t.Errorf("error = %v, want the request timeout", err)
+	}
+}
+
+func TestContextTransportCancel(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	transport := &timeoutTransport{base: http.DefaultTransport, timeout: time.Minute}
+	rmqc, err := rabbithole.NewTLSClient(srv.URL, "guest", "guest", &contextTransport{base: transport, ctx: ctx})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = rmqc.Whoami()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "request timeout") {
+		t.Errorf("error = %v, a cancelled request is not a timeout", err)
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var header atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header.Store(r.Header.Clone())
+		fmt.Fprint(w, `{"name":"guest","tags":[]}`)
+	}))
+	defer srv.Close()
+
+	model := &RabbitmqProviderModel{
+		Address:  types.StringValue(srv.URL),
+		Username: types.StringValue("guest"),
+		Password: types.StringValue("guest"),
+		Headers: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"X-Tenant":     types.StringValue("payments"),
+			"x-proxy-auth": types.StringValue("s3cret"),
+		}),
+	}
+	rmqc, _, err := configureRmqClient(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rmqc.Whoami(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := header.Load().(http.Header)
+	if got.Get("X-Tenant") != "payments" || got.Get("X-Proxy-Auth") != "s3cret" {
+		t.Errorf("headers = %v, want X-Tenant and X-Proxy-Auth", got)
+	}
+	if !strings.HasPrefix(got.Get("Authorization"), "Basic ") {
+		t.Errorf("Authorization = %q, want basic auth", got.Get("Authorization"))
+	}
+}
+
+func TestHeaderTransportInvalidHeaders(t *testing.T) {
+	tests := map[string]string{
+		"Authorization": "Bearer abc",
+		"X Tenant":      "payments",
+		"X-Tenant":      "pay\r\nments",
+		"":              "payments",
+	}
+	for name, value := range tests {
+		model := &RabbitmqProviderModel{
+			Address:  types.StringValue("http://localhost:15672"),
+			Username: types.StringValue("guest"),
+			Password: types.StringValue("guest"),
+			Headers:  types.MapValueMust(types.StringType, map[string]attr.Value{name: types.StringValue(value)}),
+		}
+		if _, _, err := configureRmqClient(model); err == nil || !strings.Contains(err.Error(), "invalid header") {
+			t.Errorf("header %q: error = %v, want invalid header", name, err)
+		}
+	}
+}
+
+func TestHeadersFromEnvironment(t *testing.T) {
+	t.Setenv("RABBITMQ_ENDPOINT", "http://localhost:15672")
+	t.Setenv("RABBITMQ_USERNAME", "guest")
+	t.Setenv("RABBITMQ_PASSWORD", "guest")
+	t.Setenv("RABBITMQ_HEADERS", "X-Tenant=payments, X-Proxy-Auth = s3cret,")
+
+	data := RabbitmqProviderModel{Headers: types.MapNull(types.StringType)}
+	if diags := applyProviderEnv(&data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"X-Tenant":     types.StringValue("payments"),
+		"X-Proxy-Auth": types.StringValue("s3cret"),
+	})
+	if !data.Headers.Equal(want) {
+		t.Errorf("headers = %s, want %s", data.Headers, want)
+	}
+
+	t.Setenv("RABBITMQ_HEADERS", "X-Tenant")
+	data = RabbitmqProviderModel{Headers: types.MapNull(types.StringType)}
+	if diags := applyProviderEnv(&data); !diags.HasError() {
+		t.Error("a header without a value was accepted")
+	}
+}